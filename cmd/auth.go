@@ -0,0 +1,202 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/keyring"
+)
+
+// Constants defining the encrypted file used when no keyring is available.
+const (
+	// keyringFilename is the name of the encrypted token file in the application directory.
+	keyringFilename = "token.enc"
+	// keyringPassphraseEnv names the environment variable holding the passphrase of the file.
+	keyringPassphraseEnv = "CF_KEYRING_PASSPHRASE"
+)
+
+// OpenKeyringFunc opens the store holding the master token, defaulting to keyring.Open.
+var OpenKeyringFunc = keyring.Open
+
+// authCmd groups the commands for storing the master token outside the configuration file.
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Store the master token in the system keyring",
+	Long: `Store the master token in the system keyring.
+
+On Linux, the token is kept in the Secret Service keyring (e.g., GNOME Keyring or
+KeePassXC). Without a D-Bus session, and on other systems, it is kept in a file
+encrypted with a passphrase, read from ` + keyringPassphraseEnv + ` or prompted for.
+
+The stored token is used when no api_token, api_token_file, or api_token_command is
+configured.`,
+}
+
+// authLoginCmd defines the command to store the master token.
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store the master token in the system keyring",
+	Long: `Store the master token in the system keyring.
+
+The token is taken from --token, --token-stdin, or the configuration, and prompted
+for without echo otherwise. An existing stored token is replaced.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Use a configured token, which moves it out of the configuration, or prompt for it.
+		token, err := configuredToken(cmd)
+		if err != nil {
+			return err
+		}
+
+		if token == "" {
+			token, err = promptSecret("Cloudflare master API token")
+			if err != nil {
+				return err
+			}
+		}
+
+		if token == "" {
+			return cloudflare.ErrMissingCredentials
+		}
+
+		store, err := openKeyring()
+		if err != nil {
+			return err
+		}
+
+		defer store.Close()
+
+		err = store.Set(token)
+		if err != nil {
+			return fmt.Errorf("failed to store the master token in the %s: %w", store.Name(), err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Stored the master token in the %s.\n", store.Name())
+
+		// A token in the configuration file takes precedence over the stored one.
+		if viper.InConfig(config.APITokenKey) {
+			fmt.Fprintf(
+				os.Stderr,
+				"Remove %s from %s, as it takes precedence over the stored token.\n",
+				config.APITokenKey,
+				viper.ConfigFileUsed(),
+			)
+		}
+
+		return nil
+	},
+}
+
+// authLogoutCmd defines the command to remove the stored master token.
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the master token from the system keyring",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		store, err := openKeyring()
+		if err != nil {
+			return err
+		}
+
+		defer store.Close()
+
+		err = store.Delete()
+		if errors.Is(err, keyring.ErrNotFound) {
+			fmt.Fprintf(os.Stderr, "No master token stored in the %s.\n", store.Name())
+
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to remove the master token from the %s: %w", store.Name(), err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Removed the master token from the %s.\n", store.Name())
+
+		return nil
+	},
+}
+
+// init configures the auth commands before execution.
+func init() {
+	// Add the auth commands to the root command.
+	authCmd.AddCommand(authLoginCmd, authLogoutCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+// openKeyring opens the store holding the master token, falling back to an encrypted
+// file in the application directory.
+func openKeyring() (keyring.Store, error) {
+	dir, err := config.AppDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, keyringFilename)
+
+	store, err := OpenKeyringFunc(keyring.Options{
+		FilePath:   path,
+		Passphrase: func() (string, error) { return keyringPassphrase(path) },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the keyring: %w", err)
+	}
+
+	return store, nil
+}
+
+// keyringPassphrase returns the passphrase of the encrypted token file from the
+// environment, or prompts for it.
+func keyringPassphrase(path string) (string, error) {
+	if passphrase := os.Getenv(keyringPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	return promptSecret("Passphrase for " + path)
+}
+
+// storedToken returns the master token stored by auth login, or an empty token if none
+// is stored.
+func storedToken() (string, error) {
+	store, err := openKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	defer store.Close()
+
+	token, err := store.Get()
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read the master token from the %s: %w", store.Name(), err)
+	}
+
+	return token, nil
+}
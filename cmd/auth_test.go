@@ -0,0 +1,261 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/keyring"
+)
+
+// memoryStore is a keyring.Store kept in memory.
+type memoryStore struct {
+	token string
+	err   error
+}
+
+func (s *memoryStore) Name() string { return "test keyring" }
+
+func (s *memoryStore) Get() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+
+	if s.token == "" {
+		return "", keyring.ErrNotFound
+	}
+
+	return s.token, nil
+}
+
+func (s *memoryStore) Set(token string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.token = token
+
+	return nil
+}
+
+func (s *memoryStore) Delete() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.token == "" {
+		return keyring.ErrNotFound
+	}
+
+	s.token = ""
+
+	return nil
+}
+
+func (s *memoryStore) Close() error { return nil }
+
+// TestMain keeps the tests away from the keyring of the user running them.
+func TestMain(m *testing.M) {
+	OpenKeyringFunc = func(_ keyring.Options) (keyring.Store, error) {
+		return &memoryStore{}, nil
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestAuthCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		apiToken   string
+		input      string
+		stored     string
+		storeErr   error
+		openErr    error
+		wantStored string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "LoginPrompt",
+			args:       []string{"auth", "login"},
+			input:      "prompted-token\n",
+			stored:     "old-token",
+			wantStored: "prompted-token",
+		},
+		{
+			name:       "LoginConfiguredToken",
+			args:       []string{"auth", "login"},
+			apiToken:   "config-token",
+			wantStored: "config-token",
+		},
+		{
+			name:       "LoginStdin",
+			args:       []string{"auth", "login", "--token-stdin"},
+			input:      "stdin-token\n",
+			wantStored: "stdin-token",
+		},
+		{
+			name:       "LoginEmptyToken",
+			args:       []string{"auth", "login"},
+			input:      "\n",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "LoginStoreError",
+			args:       []string{"auth", "login"},
+			input:      "prompted-token\n",
+			storeErr:   keyring.ErrPromptDismissed,
+			wantErr:    true,
+			wantErrMsg: "failed to store the master token in the test keyring",
+		},
+		{
+			name:       "LoginOpenError",
+			args:       []string{"auth", "login"},
+			input:      "prompted-token\n",
+			openErr:    keyring.ErrSecretServiceFailed,
+			wantErr:    true,
+			wantErrMsg: "failed to open the keyring",
+		},
+		{
+			name:   "Logout",
+			args:   []string{"auth", "logout"},
+			stored: "old-token",
+		},
+		{
+			name: "LogoutNothingStored",
+			args: []string{"auth", "logout"},
+		},
+		{
+			name:       "LogoutStoreError",
+			args:       []string{"auth", "logout"},
+			stored:     "old-token",
+			storeErr:   keyring.ErrPromptDismissed,
+			wantStored: "old-token",
+			wantErr:    true,
+			wantErrMsg: "failed to remove the master token from the test keyring",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+			origOpenKeyring := OpenKeyringFunc
+			origStdin := stdin
+
+			defer func() {
+				config.InitConfigFunc = origInitConfig
+				OpenKeyringFunc = origOpenKeyring
+				stdin = origStdin
+			}()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+			}
+			stdin = strings.NewReader(tt.input)
+
+			store := &memoryStore{token: tt.stored, err: tt.storeErr}
+
+			OpenKeyringFunc = func(_ keyring.Options) (keyring.Store, error) {
+				if tt.openErr != nil {
+					return nil, tt.openErr
+				}
+
+				return store, nil
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.PersistentFlags().Bool("token-stdin", false, "Read the Cloudflare API token from stdin")
+
+			// Drop the flags inherited from earlier test roots, which keep their parsed values.
+			authLoginCmd.ResetFlags()
+			rootCmd.AddCommand(authCmd)
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if store.token != tt.wantStored {
+				t.Errorf("stored token = %q, want %q", store.token, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestStoredToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   *memoryStore
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "Stored",
+			store: &memoryStore{token: "stored-token"},
+			want:  "stored-token",
+		},
+		{
+			name:  "NothingStored",
+			store: &memoryStore{},
+		},
+		{
+			name:    "StoreError",
+			store:   &memoryStore{err: keyring.ErrWrongPassphrase},
+			wantErr: keyring.ErrWrongPassphrase,
+		},
+	}
+
+	origOpenKeyring := OpenKeyringFunc
+
+	defer func() { OpenKeyringFunc = origOpenKeyring }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			OpenKeyringFunc = func(_ keyring.Options) (keyring.Store, error) {
+				return tt.store, nil
+			}
+
+			got, err := storedToken()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("storedToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("storedToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
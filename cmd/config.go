@@ -0,0 +1,538 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+// Constants used when creating the configuration file.
+const (
+	// configDirMode is the permission mode of the directory created for the configuration file.
+	configDirMode = 0o700
+	// configHeader is the comment written at the top of a created configuration file.
+	configHeader = "# goGenerateCFToken configuration, created by config init.\n"
+)
+
+// keyFlags maps configuration keys to the persistent flags that set them.
+var keyFlags = map[string]string{
+	"api_token":       "token",
+	"zone":            "zone",
+	"account":         "account",
+	"owner":           "owner",
+	config.ProfileKey: "profile",
+}
+
+// ListAllZonesFunc lists the zones visible to a token, defaulting to cloudflare.ListAllZones.
+var ListAllZonesFunc = cloudflare.ListAllZones
+
+// configCmd groups the commands for managing the configuration file.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration file",
+}
+
+// configInitCmd defines the command to create the configuration file.
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the configuration file interactively",
+	Long: `Create the configuration file interactively.
+
+The master API token is prompted for without echo, verified against the Cloudflare
+API, and the zones it can see are listed to pick from. The configuration is written
+with owner-only permissions to the --config path, or to the default location.
+
+Pass --token and --zone to create the configuration without prompting, for example
+from a provisioning script.`,
+	Args: cobra.NoArgs,
+	// The configuration file may not exist yet, so no profile is applied.
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		// Determine where to write the configuration file.
+		path, err := initConfigPath()
+		if err != nil {
+			return err
+		}
+
+		// Refuse to overwrite an existing file before prompting for anything.
+		err = checkOutputFile(path, force)
+		if err != nil {
+			return err
+		}
+
+		// Use the token given with --token, or prompt for it.
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token, err = promptSecret("Cloudflare master API token")
+			if err != nil {
+				return err
+			}
+		}
+
+		if token == "" {
+			return cloudflare.ErrMissingCredentials
+		}
+
+		// Verify the token by listing the zones it can see.
+		client, err := NewClientFunc(cloudflare.Credentials{APIToken: token})
+		if err != nil {
+			return fmt.Errorf("failed to initialize Cloudflare client: %w", err)
+		}
+
+		visible, err := ListAllZonesFunc(context.Background(), client)
+		if err != nil {
+			return fmt.Errorf("failed to verify token: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Token verified, %d zone(s) visible.\n", len(visible))
+
+		// Use the zones given with --zone, or ask which zone to use.
+		zoneNames, _ := cmd.Flags().GetStringSlice("zone")
+
+		zoneNames, err = selectZones(zoneNames, visible)
+		if err != nil {
+			return err
+		}
+
+		data, err := renderConfig(token, zoneNames)
+		if err != nil {
+			return err
+		}
+
+		// Write the configuration file, creating its directory if needed.
+		err = os.MkdirAll(filepath.Dir(path), configDirMode)
+		if err != nil {
+			return fmt.Errorf("failed to create configuration directory: %w", err)
+		}
+
+		err = writeFileAtomic(path, data, force)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Wrote configuration to %s\n", path)
+
+		return nil
+	},
+}
+
+// configShowCmd defines the command to show the resolved configuration.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the resolved configuration and where each value comes from",
+	Long: `Show the resolved configuration and where each value comes from.
+
+Every setting is resolved as the other commands resolve it: flags take precedence over
+CF_* environment variables, which take precedence over the active profile and then the
+configuration file. Secrets are masked.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return writeResolvedConfig(os.Stdout, cmd)
+	},
+}
+
+// configValidateCmd defines the command to validate the configuration file.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file for unknown keys, type errors, and insecure permissions",
+	Long: `Check the configuration file for unknown keys, type errors, and insecure permissions.
+
+Each problem is printed on its own line, and the command exits with a non-zero status
+if any are found, so that it can be used as a CI check.`,
+	Args: cobra.NoArgs,
+	// The file is checked as-is, so that a broken profile is reported rather than fatal.
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	RunE: func(_ *cobra.Command, _ []string) error {
+		path := viper.ConfigFileUsed()
+
+		problems, err := config.ValidateFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stdout, "%s: %s\n", path, problem)
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("%w: %d problem(s) in %s", ErrInvalidConfig, len(problems), path)
+		}
+
+		fmt.Fprintf(os.Stderr, "Configuration file %s is valid.\n", path)
+
+		return nil
+	},
+}
+
+// configEncryptCmd defines the command to encrypt the configuration file.
+var configEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the configuration file with age or sops",
+	Long: `Encrypt the configuration file with age or sops.
+
+The whole file is encrypted in the sops format, which the sops tool can also edit and
+decrypt. With --token-only, only api_token is encrypted with age, leaving the other
+settings readable. Comments are encrypted too, except with --token-only.
+
+The file is encrypted for the --recipient public keys, or for the identities in the
+age key file named by SOPS_AGE_KEY_FILE, by default ~/.config/age/keys.txt. The result
+is printed, or written back to the file with --in-place.`,
+	Args: cobra.NoArgs,
+	// The file is read as-is, so that no profile is applied.
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		recipients, _ := cmd.Flags().GetStringSlice("recipient")
+		tokenOnly, _ := cmd.Flags().GetBool("token-only")
+
+		// Encrypt for the configured identities if no recipient was given.
+		if len(recipients) == 0 {
+			identities, err := config.LoadAgeIdentities()
+			if err != nil {
+				return err
+			}
+
+			recipients = config.IdentityRecipients(identities)
+		}
+
+		return transformConfigFile(cmd, func(data []byte) ([]byte, error) {
+			return config.Encrypt(data, recipients, tokenOnly)
+		})
+	},
+}
+
+// configDecryptCmd defines the command to decrypt the configuration file.
+var configDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt the configuration file",
+	Long: `Decrypt the configuration file.
+
+Both sops documents and values encrypted with age are decrypted with the identities in
+the age key file named by SOPS_AGE_KEY_FILE, by default ~/.config/age/keys.txt. The
+result is printed, or written back to the file with --in-place.`,
+	Args: cobra.NoArgs,
+	// The file is read as-is, so that no profile is applied.
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return transformConfigFile(cmd, func(data []byte) ([]byte, error) {
+			if !config.IsEncrypted(data) {
+				return nil, ErrNotEncrypted
+			}
+
+			return config.Decrypt(data)
+		})
+	},
+}
+
+// init configures the config commands before execution.
+func init() {
+	addConfigInitFlags()
+	addConfigEncryptFlags()
+
+	// Add the config commands to the root command.
+	configCmd.AddCommand(configInitCmd, configShowCmd, configValidateCmd, configEncryptCmd, configDecryptCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// addConfigInitFlags defines the flags of the config init command.
+func addConfigInitFlags() {
+	configInitCmd.Flags().Bool("force", false, "Overwrite an existing configuration file")
+}
+
+// addConfigEncryptFlags defines the flags of the config encrypt and decrypt commands.
+func addConfigEncryptFlags() {
+	configEncryptCmd.Flags().StringSliceP("recipient", "r", nil, "age public key to encrypt for (can be repeated)")
+	configEncryptCmd.Flags().Bool("token-only", false, "Encrypt only api_token with age")
+
+	for _, cmd := range []*cobra.Command{configEncryptCmd, configDecryptCmd} {
+		cmd.Flags().BoolP("in-place", "i", false, "Write the result back to the configuration file")
+	}
+}
+
+// transformConfigFile applies transform to the configuration file in use, printing the
+// result or, with --in-place, replacing the file with it.
+func transformConfigFile(cmd *cobra.Command, transform func(data []byte) ([]byte, error)) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return config.ErrNoConfigFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	data, err = transform(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	inPlace, _ := cmd.Flags().GetBool("in-place")
+	if !inPlace {
+		_, err = os.Stdout.Write(data)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		return nil
+	}
+
+	err = writeFileAtomic(path, data, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+
+	return nil
+}
+
+// initConfigPath returns the path of the configuration file to create: the --config
+// path if given, otherwise config.yaml in the application directory.
+func initConfigPath() (string, error) {
+	if config.ConfigFile != "" {
+		return config.ConfigFile, nil
+	}
+
+	dir, err := config.AppDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate configuration directory: %w", err)
+	}
+
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// selectZones returns the zones to configure. Zones that were given are checked against
+// the visible zones; otherwise the user picks one of the visible zones. A token that
+// cannot list zones at all is trusted with the zones given, as it may be scoped to them.
+func selectZones(zoneNames []string, visible []cloudflare.ZoneSummary) ([]string, error) {
+	if len(zoneNames) > 0 {
+		names := make([]string, 0, len(zoneNames))
+
+		for _, name := range zoneNames {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" || slices.Contains(names, name) {
+				continue
+			}
+
+			if len(visible) > 0 && !slices.ContainsFunc(visible, func(z cloudflare.ZoneSummary) bool {
+				return strings.EqualFold(z.Name, name)
+			}) {
+				return nil, fmt.Errorf("%w: %s", ErrZoneNotVisible, name)
+			}
+
+			names = append(names, name)
+		}
+
+		if len(names) == 0 {
+			return nil, ErrMissingConfigZone
+		}
+
+		return names, nil
+	}
+
+	if len(visible) == 0 {
+		return nil, ErrNoVisibleZones
+	}
+
+	// Select the only zone without asking.
+	if len(visible) == 1 {
+		fmt.Fprintf(os.Stderr, "Using zone %s.\n", visible[0].Name)
+
+		return []string{visible[0].Name}, nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Zones:")
+
+	for i, zone := range visible {
+		fmt.Fprintf(os.Stderr, "  %d) %s (%s)\n", i+1, zone.Name, zone.AccountName)
+	}
+
+	answer, err := prompt(fmt.Sprintf("Select a zone [1-%d]", len(visible)))
+	if err != nil {
+		return nil, err
+	}
+
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(visible) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSelection, answer)
+	}
+
+	return []string{visible[choice-1].Name}, nil
+}
+
+// renderConfig renders the configuration file for a master token and its zones.
+// A single zone is written to the zone key, and several to the zones list.
+func renderConfig(token string, zoneNames []string) ([]byte, error) {
+	settings := map[string]any{"api_token": token}
+
+	if len(zoneNames) == 1 {
+		settings["zone"] = zoneNames[0]
+	} else {
+		settings["zones"] = zoneNames
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(configHeader)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(profileIndent)
+
+	err := encoder.Encode(settings)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeResolvedConfig writes a table of the set configuration values to w, with the
+// origin of each value and secrets masked.
+func writeResolvedConfig(w io.Writer, cmd *cobra.Command) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		path = "none"
+	}
+
+	fmt.Fprintf(w, "# Config file: %s\n", path)
+
+	table := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	fmt.Fprintln(table, "KEY\tVALUE\tORIGIN")
+
+	for _, setting := range config.Settings {
+		if !viper.IsSet(setting.Key) {
+			continue
+		}
+
+		origin := valueOrigin(cmd, setting.Key)
+		value := viper.Get(setting.Key)
+
+		// Show each custom preset on its own row.
+		if setting.Type == config.TypePresets {
+			presets := viper.GetStringMapStringSlice(setting.Key)
+
+			for _, name := range slices.Sorted(maps.Keys(presets)) {
+				fmt.Fprintf(table, "%s.%s\t%s\t%s\n", setting.Key, name, strings.Join(presets[name], ", "), origin)
+			}
+
+			continue
+		}
+
+		// Show only the names of profiles, whose settings are shown by profile show.
+		if setting.Type == config.TypeProfiles {
+			value = slices.Sorted(maps.Keys(viper.GetStringMap(setting.Key)))
+		}
+
+		text := formatValue(value)
+		if slices.Contains(secretKeys, setting.Key) && text != "" {
+			text = maskSecret(text)
+		}
+
+		if text == "" {
+			text = emptyCell
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\n", setting.Key, text, origin)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	return nil
+}
+
+// valueOrigin describes where the value of a configuration key comes from, following
+// the precedence of flags, environment variables, the active profile, and the file.
+func valueOrigin(cmd *cobra.Command, key string) string {
+	if name, ok := keyFlags[key]; ok {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return "flag --" + name
+		}
+	}
+
+	env := "CF_" + strings.ToUpper(key)
+	if os.Getenv(env) != "" {
+		return "env " + env
+	}
+
+	if profile := config.ActiveProfile(viper.GetViper()); profile != "" {
+		settings, err := config.Profile(viper.GetViper(), profile)
+		if _, ok := settings[key]; ok && err == nil {
+			return "profile " + profile
+		}
+	}
+
+	if viper.InConfig(key) {
+		return "file"
+	}
+
+	return "default"
+}
+
+// formatValue formats a configuration value for display, joining lists with commas.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,651 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+func TestConfigInitCmd(t *testing.T) {
+	visibleZones := []cloudflare.ZoneSummary{
+		{ID: "zone-1", Name: "example.com", AccountName: "Personal"},
+		{ID: "zone-2", Name: "example.org", AccountName: "Work"},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		input      string
+		existing   string
+		zones      []cloudflare.ZoneSummary
+		listErr    error
+		wantToken  string
+		wantConfig string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "Interactive",
+			args:       []string{"config", "init"},
+			input:      "prompted-token\n2\n",
+			zones:      visibleZones,
+			wantToken:  "prompted-token",
+			wantConfig: "api_token: prompted-token\nzone: example.org\n",
+		},
+		{
+			name:       "InteractiveSingleZone",
+			args:       []string{"config", "init"},
+			input:      "prompted-token\n",
+			zones:      visibleZones[:1],
+			wantToken:  "prompted-token",
+			wantConfig: "api_token: prompted-token\nzone: example.com\n",
+		},
+		{
+			name:       "InteractiveInvalidSelection",
+			args:       []string{"config", "init"},
+			input:      "prompted-token\n3\n",
+			zones:      visibleZones,
+			wantErr:    true,
+			wantErrMsg: ErrInvalidSelection.Error(),
+		},
+		{
+			name:       "NonInteractive",
+			args:       []string{"config", "init", "--token", "flag-token", "--zone", "Example.com"},
+			zones:      visibleZones,
+			wantToken:  "flag-token",
+			wantConfig: "api_token: flag-token\nzone: example.com\n",
+		},
+		{
+			name:       "NonInteractiveMultipleZones",
+			args:       []string{"config", "init", "-t", "flag-token", "-z", "example.com,example.org"},
+			zones:      visibleZones,
+			wantConfig: "api_token: flag-token\nzones:\n  - example.com\n  - example.org\n",
+		},
+		{
+			name:       "NonInteractiveZoneNotVisible",
+			args:       []string{"config", "init", "--token", "flag-token", "--zone", "example.net"},
+			zones:      visibleZones,
+			wantErr:    true,
+			wantErrMsg: ErrZoneNotVisible.Error(),
+		},
+		{
+			name:       "ZoneScopedToken",
+			args:       []string{"config", "init", "--token", "flag-token", "--zone", "example.net"},
+			wantConfig: "api_token: flag-token\nzone: example.net\n",
+		},
+		{
+			name:       "NoVisibleZones",
+			args:       []string{"config", "init"},
+			input:      "prompted-token\n",
+			wantErr:    true,
+			wantErrMsg: ErrNoVisibleZones.Error(),
+		},
+		{
+			name:       "EmptyToken",
+			args:       []string{"config", "init"},
+			input:      "\n",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "VerifyError",
+			args:       []string{"config", "init", "--token", "bad-token"},
+			listErr:    errors.New("invalid token"),
+			wantErr:    true,
+			wantErrMsg: "failed to verify token",
+		},
+		{
+			name:       "ExistingFile",
+			args:       []string{"config", "init", "--token", "flag-token", "--zone", "example.com"},
+			existing:   "api_token: old-token\n",
+			zones:      visibleZones,
+			wantConfig: "api_token: old-token\n",
+			wantErr:    true,
+			wantErrMsg: ErrOutputFileExists.Error(),
+		},
+		{
+			name:       "ExistingFileForced",
+			args:       []string{"config", "init", "--token", "flag-token", "--zone", "example.com", "--force"},
+			existing:   "api_token: old-token\n",
+			zones:      visibleZones,
+			wantConfig: "api_token: flag-token\nzone: example.com\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+			if tt.existing != "" {
+				err := os.MkdirAll(filepath.Dir(path), 0o700)
+				if err == nil {
+					err = os.WriteFile(path, []byte(tt.existing), 0o600)
+				}
+
+				if err != nil {
+					t.Fatalf("Failed to write config: %v", err)
+				}
+			}
+
+			origConfigFile := config.ConfigFile
+			origInitConfig := config.InitConfigFunc
+			origNewClient := NewClientFunc
+			origListAllZones := ListAllZonesFunc
+			origStdin := stdin
+
+			defer func() {
+				config.ConfigFile = origConfigFile
+				config.InitConfigFunc = origInitConfig
+				NewClientFunc = origNewClient
+				ListAllZonesFunc = origListAllZones
+				stdin = origStdin
+			}()
+
+			config.ConfigFile = path
+			config.InitConfigFunc = func(_ config.Viper) {}
+			stdin = strings.NewReader(tt.input)
+
+			var gotToken string
+
+			NewClientFunc = func(creds cloudflare.Credentials) (*cloudflare.Client, error) {
+				gotToken = creds.APIToken
+
+				return &cloudflare.Client{}, nil
+			}
+			ListAllZonesFunc = func(_ context.Context, _ cloudflare.APIInterface) ([]cloudflare.ZoneSummary, error) {
+				return tt.zones, tt.listErr
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.PersistentFlags().StringP("token", "t", "", "Cloudflare API token")
+			rootCmd.PersistentFlags().StringSliceP("zone", "z", nil, "Cloudflare zone name (repeatable)")
+
+			configInitCmd.ResetFlags()
+			addConfigInitFlags()
+			rootCmd.AddCommand(configCmd)
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if tt.wantToken != "" && gotToken != tt.wantToken {
+				t.Errorf("NewClientFunc() token = %q, want %q", gotToken, tt.wantToken)
+			}
+
+			got, err := os.ReadFile(path)
+			if tt.wantConfig == "" {
+				if err == nil {
+					t.Errorf("config file = %q, want none", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+
+			if content := strings.TrimPrefix(string(got), configHeader); content != tt.wantConfig {
+				t.Errorf("config file = %q, want %q", content, tt.wantConfig)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Failed to stat config: %v", err)
+			}
+
+			if mode := info.Mode().Perm(); mode != outputFileMode {
+				t.Errorf("config file mode = %o, want %o", mode, outputFileMode)
+			}
+		})
+	}
+}
+
+func TestConfigShowCmd(t *testing.T) {
+	viper.Reset()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err := os.WriteFile(path, []byte(testProfileConfig+"presets:\n  ci: [Zone Read, Cache Purge]\ndry_run: true\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("CF_ACCOUNT", "Personal")
+
+	origInitConfig := config.InitConfigFunc
+
+	defer func() { config.InitConfigFunc = origInitConfig }()
+
+	config.InitConfigFunc = func(v config.Viper) {
+		v.SetConfigFile(path)
+		v.SetEnvPrefix("CF")
+		v.AutomaticEnv()
+
+		err := v.ReadInConfig()
+		if err != nil {
+			t.Errorf("Failed to read config: %v", err)
+		}
+	}
+
+	rootCmd := &cobra.Command{Use: "goGenerateCFToken", PersistentPreRunE: applyProfile}
+	rootCmd.PersistentFlags().StringP("token", "t", "", "Cloudflare API token")
+	rootCmd.PersistentFlags().StringP("account", "a", "", "Cloudflare account name or ID owning the zones")
+	rootCmd.AddCommand(configCmd)
+
+	err = viper.BindPFlag("api_token", rootCmd.PersistentFlags().Lookup("token"))
+	if err != nil {
+		t.Fatalf("Failed to bind api_token: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	defer func() { os.Stdout = oldStdout }()
+
+	rootCmd.SetArgs([]string{"config", "show", "--token", "flag-token-abcd"})
+	err = rootCmd.Execute()
+
+	w.Close()
+
+	var buf bytes.Buffer
+
+	_, _ = io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("rootCmd.Execute() error = %v", err)
+	}
+
+	want := "# Config file: " + path + `
+KEY              VALUE                   ORIGIN
+api_token        ********abcd            flag --token
+zone             staging.example.com     profile staging
+account          Personal                env CF_ACCOUNT
+current_profile  staging                 file
+profiles         production, staging     file
+presets.ci       Zone Read, Cache Purge  file
+dry_run          true                    file
+`
+
+	if output := buf.String(); output != want {
+		t.Errorf("rootCmd.Execute() output = %q, want %q", output, want)
+	}
+}
+
+func TestConfigValidateCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:   "Valid",
+			config: testProfileConfig,
+		},
+		{
+			name:       "Problems",
+			config:     "api_token: token\nzome: example.com\ndry_run: yes please\n",
+			wantOutput: "dry_run: expected boolean, got string\nzome: unknown key\n",
+			wantErr:    true,
+		},
+		{
+			name:       "UnknownCurrentProfileIsReported",
+			config:     "current_profile: deleted\n",
+			wantOutput: "current_profile: unknown profile \"deleted\"\n",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.config), 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetConfigFile(path)
+				_ = v.ReadInConfig()
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken", PersistentPreRunE: applyProfile}
+			rootCmd.AddCommand(configCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs([]string{"config", "validate"})
+			err = rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("rootCmd.Execute() error = %v, want %v", err, ErrInvalidConfig)
+			}
+
+			wantOutput := ""
+			for line := range strings.Lines(tt.wantOutput) {
+				wantOutput += path + ": " + line
+			}
+
+			if output := buf.String(); output != wantOutput {
+				t.Errorf("rootCmd.Execute() output = %q, want %q", output, wantOutput)
+			}
+		})
+	}
+}
+
+func TestConfigCmd_UndecryptableConfig(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+
+	encrypted, err := config.Encrypt([]byte("api_token: secret-token\n"), []string{identity.Recipient().String()}, false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// No identity is available to decrypt the file with.
+	t.Setenv(config.AgeKeyFileEnv, filepath.Join(t.TempDir(), "missing.txt"))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "ValidateReportsIt",
+			args:    []string{"config", "validate"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "ShowFails",
+			args:    []string{"config", "show"},
+			wantErr: config.ErrNoAgeIdentity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, encrypted, 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			origConfigFile := config.ConfigFile
+			origInitConfig := config.InitConfigFunc
+
+			defer func() {
+				config.ConfigFile = origConfigFile
+				config.InitConfigFunc = origInitConfig
+			}()
+
+			// Load the file as the program does, so that the decryption error is kept.
+			config.ConfigFile = path
+			config.InitConfigFunc = nil
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken", PersistentPreRunE: applyProfile}
+			rootCmd.AddCommand(configCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err = rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("rootCmd.Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == ErrInvalidConfig && !strings.Contains(buf.String(), config.ErrNoAgeIdentity.Error()) {
+				t.Errorf("rootCmd.Execute() output = %q, want it to contain %q", buf.String(), config.ErrNoAgeIdentity)
+			}
+		})
+	}
+}
+
+func TestConfigEncryptDecryptCmd(t *testing.T) {
+	const plaintext = "api_token: secret-token\nzone: example.com\n"
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+
+	err = os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write age key file: %v", err)
+	}
+
+	t.Setenv(config.AgeKeyFileEnv, keyFile)
+
+	recipients := []string{identity.Recipient().String()}
+
+	encrypted, err := config.Encrypt([]byte(plaintext), recipients, false)
+	if err != nil {
+		t.Fatalf("Failed to encrypt config: %v", err)
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		// config is the content of the configuration file before the command runs.
+		config string
+		// wantOutput is the expected output, or wantEncrypted if the output is encrypted.
+		wantOutput    string
+		wantEncrypted bool
+		// wantFile is the expected content of the file afterwards, if it is not encrypted.
+		wantFile          string
+		wantFileEncrypted bool
+		wantErr           error
+	}{
+		{
+			name:          "EncryptToStdout",
+			args:          []string{"config", "encrypt"},
+			config:        plaintext,
+			wantEncrypted: true,
+			wantFile:      plaintext,
+		},
+		{
+			name:              "EncryptInPlace",
+			args:              []string{"config", "encrypt", "--in-place"},
+			config:            plaintext,
+			wantFileEncrypted: true,
+		},
+		{
+			name:          "EncryptForRecipient",
+			args:          []string{"config", "encrypt", "--recipient", other.Recipient().String()},
+			config:        plaintext,
+			wantEncrypted: true,
+			wantFile:      plaintext,
+		},
+		{
+			name:          "EncryptTokenOnly",
+			args:          []string{"config", "encrypt", "--token-only"},
+			config:        plaintext,
+			wantEncrypted: true,
+			wantFile:      plaintext,
+		},
+		{
+			name:     "EncryptAlreadyEncrypted",
+			args:     []string{"config", "encrypt"},
+			config:   string(encrypted),
+			wantFile: string(encrypted),
+			wantErr:  config.ErrAlreadyEncrypted,
+		},
+		{
+			name:       "DecryptToStdout",
+			args:       []string{"config", "decrypt"},
+			config:     string(encrypted),
+			wantOutput: plaintext,
+			wantFile:   string(encrypted),
+		},
+		{
+			name:     "DecryptInPlace",
+			args:     []string{"config", "decrypt", "-i"},
+			config:   string(encrypted),
+			wantFile: plaintext,
+		},
+		{
+			name:     "DecryptPlaintext",
+			args:     []string{"config", "decrypt"},
+			config:   plaintext,
+			wantFile: plaintext,
+			wantErr:  ErrNotEncrypted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.config), 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetConfigFile(path)
+				_ = v.ReadInConfig()
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken", PersistentPreRunE: applyProfile}
+
+			configEncryptCmd.ResetFlags()
+			configDecryptCmd.ResetFlags()
+			addConfigEncryptFlags()
+			rootCmd.AddCommand(configCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err = rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("rootCmd.Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			output := buf.String()
+
+			switch {
+			case tt.wantEncrypted:
+				if !config.IsEncrypted([]byte(output)) || strings.Contains(output, "secret-token") {
+					t.Errorf("rootCmd.Execute() output = %q, want encrypted config", output)
+				}
+			case output != tt.wantOutput:
+				t.Errorf("rootCmd.Execute() output = %q, want %q", output, tt.wantOutput)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+
+			switch {
+			case tt.wantFileEncrypted:
+				decrypted, err := config.Decrypt(data)
+				if err != nil || string(decrypted) != plaintext {
+					t.Errorf("Decrypted config file = %q, %v, want %q", decrypted, err, plaintext)
+				}
+			case string(data) != tt.wantFile:
+				t.Errorf("Config file = %q, want %q", data, tt.wantFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// credentialWriter writes a generated token in the credential file format of an ACME client.
+type credentialWriter func(w io.Writer, token cloudflare.GeneratedToken)
+
+// credentialFormats maps the supported --format values to their credential writers.
+var credentialFormats = map[string]credentialWriter{
+	"certbot": writeCertbotCredentials,
+	"lego":    writeLegoCredentials,
+	"traefik": writeLegoCredentials,
+	"acme.sh": writeAcmeShCredentials,
+	"caddy":   writeCaddyCredentials,
+}
+
+// credentialFormatNames returns the sorted names of the supported credential formats.
+func credentialFormatNames() []string {
+	return slices.Sorted(maps.Keys(credentialFormats))
+}
+
+// validateCredentialFormat checks that format is empty or a supported credential format.
+func validateCredentialFormat(format string) error {
+	if _, ok := credentialFormats[format]; format != "" && !ok {
+		return fmt.Errorf(
+			"%w: %q (supported: %s)",
+			ErrUnsupportedFormat,
+			format,
+			strings.Join(credentialFormatNames(), ", "),
+		)
+	}
+
+	return nil
+}
+
+// writeCredentials writes a generated token to w in the given credential format.
+func writeCredentials(w io.Writer, format string, token cloudflare.GeneratedToken) error {
+	writer, ok := credentialFormats[format]
+	if !ok {
+		return validateCredentialFormat(format)
+	}
+
+	writer(w, token)
+
+	return nil
+}
+
+// writeCertbotCredentials writes a cloudflare.ini credentials file for the
+// certbot-dns-cloudflare plugin.
+func writeCertbotCredentials(w io.Writer, token cloudflare.GeneratedToken) {
+	fmt.Fprintf(w, "# Cloudflare API token %s for certbot-dns-cloudflare\n", token.Name)
+	fmt.Fprintf(w, "dns_cloudflare_api_token = %s\n", token.Value)
+}
+
+// writeLegoCredentials writes an environment file for lego, which is also used by Traefik.
+// The same token is used for both DNS edits and zone lookups.
+func writeLegoCredentials(w io.Writer, token cloudflare.GeneratedToken) {
+	fmt.Fprintf(w, "# Cloudflare API token %s for lego/Traefik\n", token.Name)
+	fmt.Fprintf(w, "CF_DNS_API_TOKEN=%s\n", token.Value)
+	fmt.Fprintf(w, "CF_ZONE_API_TOKEN=%s\n", token.Value)
+}
+
+// writeAcmeShCredentials writes an environment file for acme.sh's dns_cf hook.
+// The zone ID is included for single-zone tokens, so acme.sh does not need to list zones.
+func writeAcmeShCredentials(w io.Writer, token cloudflare.GeneratedToken) {
+	fmt.Fprintf(w, "# Cloudflare API token %s for acme.sh (dns_cf)\n", token.Name)
+	fmt.Fprintf(w, "CF_Token=%s\n", token.Value)
+
+	if len(token.ZoneIDs) == 1 {
+		fmt.Fprintf(w, "CF_Zone_ID=%s\n", token.ZoneIDs[0])
+	}
+}
+
+// writeCaddyCredentials writes an environment file for the caddy-dns/cloudflare module,
+// referenced from a Caddyfile as {env.CF_API_TOKEN}.
+func writeCaddyCredentials(w io.Writer, token cloudflare.GeneratedToken) {
+	fmt.Fprintf(w, "# Cloudflare API token %s for Caddy\n", token.Name)
+	fmt.Fprintln(w, "# Caddyfile: tls { dns cloudflare {env.CF_API_TOKEN} }")
+	fmt.Fprintf(w, "CF_API_TOKEN=%s\n", token.Value)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+func TestWriteCredentials(t *testing.T) {
+	token := cloudflare.GeneratedToken{
+		ID:      "token-id-123",
+		Name:    "certs.example.com",
+		Value:   "secret-value",
+		ZoneIDs: []string{"zone-id-123"},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		token   cloudflare.GeneratedToken
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "Certbot",
+			format: "certbot",
+			token:  token,
+			want: "# Cloudflare API token certs.example.com for certbot-dns-cloudflare\n" +
+				"dns_cloudflare_api_token = secret-value\n",
+		},
+		{
+			name:   "Lego",
+			format: "lego",
+			token:  token,
+			want: "# Cloudflare API token certs.example.com for lego/Traefik\n" +
+				"CF_DNS_API_TOKEN=secret-value\nCF_ZONE_API_TOKEN=secret-value\n",
+		},
+		{
+			name:   "Traefik",
+			format: "traefik",
+			token:  token,
+			want: "# Cloudflare API token certs.example.com for lego/Traefik\n" +
+				"CF_DNS_API_TOKEN=secret-value\nCF_ZONE_API_TOKEN=secret-value\n",
+		},
+		{
+			name:   "AcmeSh",
+			format: "acme.sh",
+			token:  token,
+			want: "# Cloudflare API token certs.example.com for acme.sh (dns_cf)\n" +
+				"CF_Token=secret-value\nCF_Zone_ID=zone-id-123\n",
+		},
+		{
+			name:   "AcmeShMultipleZones",
+			format: "acme.sh",
+			token: cloudflare.GeneratedToken{
+				Name:    "certs.example.com,example.org",
+				Value:   "secret-value",
+				ZoneIDs: []string{"zone-id-123", "zone-id-456"},
+			},
+			want: "# Cloudflare API token certs.example.com,example.org for acme.sh (dns_cf)\n" +
+				"CF_Token=secret-value\n",
+		},
+		{
+			name:   "Caddy",
+			format: "caddy",
+			token:  token,
+			want: "# Cloudflare API token certs.example.com for Caddy\n" +
+				"# Caddyfile: tls { dns cloudflare {env.CF_API_TOKEN} }\n" +
+				"CF_API_TOKEN=secret-value\n",
+		},
+		{
+			name:    "Unsupported",
+			format:  "nginx",
+			token:   token,
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeCredentials(&buf, tt.format, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("writeCredentials() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeCredentials() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCredentialFormat(t *testing.T) {
+	if err := validateCredentialFormat(""); err != nil {
+		t.Errorf("validateCredentialFormat(\"\") error = %v, want nil", err)
+	}
+
+	if err := validateCredentialFormat("certbot"); err != nil {
+		t.Errorf("validateCredentialFormat(\"certbot\") error = %v, want nil", err)
+	}
+
+	if err := validateCredentialFormat("nginx"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("validateCredentialFormat(\"nginx\") error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
@@ -1,14 +1,32 @@
 // Package cmd provides the command-line interface for the goGenerateCFToken tool.
 //
 // The goGenerateCFToken CLI generates Cloudflare API tokens with DNS edit permissions.
-// It supports a single command, "generate", which creates a token based on a provided
-// service name and configuration settings (API token and zone name). The tool uses
-// Cobra for command handling and Viper for configuration management.
+// The tool uses Cobra for command handling and Viper for configuration management.
 //
-// The root command, "goGenerateCFToken", initializes the CLI and supports a persistent
-// --config flag to specify the configuration file path. The configuration file contains
-// the Cloudflare API token and zone name, which can also be set via flags (--token, --zone)
-// or environment variables (CF_API_TOKEN, CF_ZONE).
+// Commands:
+//   - auth login/logout: Stores the master token in the system keyring, or removes it.
+//   - config init: Creates the configuration file, prompting for and verifying the master token.
+//   - config show/validate: Shows the resolved settings and their origins, or checks the file.
+//   - config encrypt/decrypt: Encrypts the configuration file with age or sops, or decrypts it.
+//   - doctor: Checks that the master token is active and can create tokens for the zones.
+//   - generate: Creates a token based on a provided service name and configuration
+//     settings (API token and zone name), optionally writing it as an ACME client
+//     credential file.
+//   - list: Shows the tokens owned by the master token.
+//   - permissions list: Shows the permission groups that can be granted to tokens.
+//   - profile list/use/show: Lists, selects, and shows the named configuration profiles.
+//   - revoke: Deletes tokens by service name, token name, or ID.
+//   - rotate: Rolls the secret of an existing service token, keeping its ID and policies.
+//
+// The root command, "goGenerateCFToken", initializes the CLI and supports persistent
+// --config, --token, and --zone flags shared by all commands. The configuration file
+// contains the Cloudflare API token and zone name, which can also be set via flags
+// (--token, --zone) or environment variables (CF_API_TOKEN, CF_ZONE). The token can
+// instead be read from a file (api_token_file), a command (api_token_command), or stdin
+// (--token-stdin), and is otherwise taken from the keyring if stored by auth login.
+// Without a token, the legacy Global API Key (api_key and api_email) is used, with a warning.
+// With --owner account, the commands manage tokens owned by the --account ID instead of
+// the user.
 //
 // Example usage:
 //
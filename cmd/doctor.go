@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// doctorCmd defines the command to check the master token and its permissions.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the master token is active and can create tokens for the configured zones",
+	Long: `Check that the master token is active and can create tokens for the configured zones.
+
+The master token is verified against the Cloudflare API, and its expiry, its access to
+each configured zone, and its permission to create API tokens are reported. The command
+exits with a non-zero status if any check fails. generate runs the same checks.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Retrieve the master API token or Global API Key from its configured source.
+		creds, err := masterCredentials(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		zoneNames := configuredZones()
+
+		result := PreflightFunc(context.Background(), client, zoneNames, viper.GetString("account"))
+
+		writePreflight(os.Stdout, result, len(zoneNames) == 0)
+
+		err = result.Err()
+		if err != nil {
+			return fmt.Errorf("master token check failed: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// init configures the doctor command before execution.
+func init() {
+	// Add the doctor command to the root command.
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// writePreflight writes one line per preflight check to w, noting when the zone checks
+// were skipped because no zones are configured.
+func writePreflight(w io.Writer, result cloudflare.PreflightResult, noZones bool) {
+	for _, check := range result {
+		name := check.Name
+		if check.Subject != "" {
+			name += " " + check.Subject
+		}
+
+		if check.Err != nil {
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", name, check.Err)
+		} else {
+			fmt.Fprintf(w, "[ OK ] %s: %s\n", name, check.Detail)
+		}
+
+		// Report the skipped zone checks after the token check, where they would have run.
+		if noZones && check.Name == cloudflare.CheckTokenActive && check.Err == nil {
+			fmt.Fprintf(w, "[SKIP] %s: no zones configured\n", cloudflare.CheckZoneAccess)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+func TestDoctorCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiToken   string
+		zone       string
+		account    string
+		preflight  cloudflare.PreflightResult
+		wantZones  []string
+		wantOutput string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:     "AllChecksPass",
+			apiToken: "valid-token",
+			zone:     "example.com",
+			account:  "Personal",
+			preflight: cloudflare.PreflightResult{
+				{Name: cloudflare.CheckTokenActive, Detail: "active, never expires"},
+				{Name: cloudflare.CheckZoneAccess, Subject: "example.com", Detail: "zone zone-id-123"},
+				{Name: cloudflare.CheckCreateTokens, Detail: "granted API Tokens: Edit"},
+			},
+			wantZones: []string{"example.com"},
+			wantOutput: "[ OK ] token active: active, never expires\n" +
+				"[ OK ] zone access example.com: zone zone-id-123\n" +
+				"[ OK ] create tokens: granted API Tokens: Edit\n",
+		},
+		{
+			name:     "NoZones",
+			apiToken: "valid-token",
+			preflight: cloudflare.PreflightResult{
+				{Name: cloudflare.CheckTokenActive, Detail: "active, never expires"},
+				{Name: cloudflare.CheckCreateTokens, Detail: "granted API Tokens: Edit"},
+			},
+			wantOutput: "[ OK ] token active: active, never expires\n" +
+				"[SKIP] zone access: no zones configured\n" +
+				"[ OK ] create tokens: granted API Tokens: Edit\n",
+		},
+		{
+			name:     "CheckFails",
+			apiToken: "valid-token",
+			zone:     "example.org",
+			preflight: cloudflare.PreflightResult{
+				{Name: cloudflare.CheckTokenActive, Detail: "active, never expires"},
+				{Name: cloudflare.CheckZoneAccess, Subject: "example.org", Err: cloudflare.ErrZoneNotAccessible},
+				{Name: cloudflare.CheckCreateTokens, Err: cloudflare.ErrMissingTokenPermission},
+			},
+			wantZones: []string{"example.org"},
+			wantOutput: "[ OK ] token active: active, never expires\n" +
+				"[FAIL] zone access example.org: " + cloudflare.ErrZoneNotAccessible.Error() + "\n" +
+				"[FAIL] create tokens: " + cloudflare.ErrMissingTokenPermission.Error() + "\n",
+			wantErr:    true,
+			wantErrMsg: "master token check failed: " + cloudflare.ErrZoneNotAccessible.Error(),
+		},
+		{
+			name:       "MissingAPIToken",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+				v.SetDefault("zone", tt.zone)
+				v.SetDefault("account", tt.account)
+			}
+
+			origNewClient := NewClientFunc
+			origPreflight := PreflightFunc
+
+			defer func() {
+				NewClientFunc = origNewClient
+				PreflightFunc = origPreflight
+			}()
+
+			NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return &cloudflare.Client{}, nil
+			}
+
+			var gotZones []string
+
+			var gotAccount string
+
+			PreflightFunc = func(_ context.Context, _ cloudflare.APIInterface, zoneNames []string, account string) cloudflare.PreflightResult {
+				gotZones = zoneNames
+				gotAccount = account
+
+				return tt.preflight
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.AddCommand(doctorCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs([]string{"doctor"})
+			err := rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if output := buf.String(); output != tt.wantOutput {
+				t.Errorf("rootCmd.Execute() output = %q, want %q", output, tt.wantOutput)
+			}
+
+			if tt.preflight != nil && (!slices.Equal(gotZones, tt.wantZones) || gotAccount != tt.account) {
+				t.Errorf("PreflightFunc() zones = %q, account = %q, want %q, %q", gotZones, gotAccount, tt.wantZones, tt.account)
+			}
+		})
+	}
+}
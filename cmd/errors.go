@@ -28,4 +28,55 @@ var (
 
 	// ErrBindZoneFlag indicates a failure to bind the zone flag to the configuration.
 	ErrBindZoneFlag = errors.New("failed to bind zone flag")
+
+	// ErrBindFlag indicates a failure to bind a command flag to the configuration.
+	ErrBindFlag = errors.New("failed to bind flag")
+
+	// ErrReadInput indicates a failure to read interactive input.
+	ErrReadInput = errors.New("failed to read input")
+
+	// ErrUnsupportedOutput indicates an output format that is not supported.
+	ErrUnsupportedOutput = errors.New("unsupported output format")
+
+	// ErrUnsupportedFormat indicates a credential file format that is not supported.
+	ErrUnsupportedFormat = errors.New("unsupported credential format")
+
+	// ErrConflictingOutput indicates that both a credential format and a structured output format were requested.
+	ErrConflictingOutput = errors.New("--format can only be combined with --output text")
+
+	// ErrOutputFileExists indicates that the output file already exists and --force was not given.
+	ErrOutputFileExists = errors.New("output file already exists (use --force to overwrite)")
+
+	// ErrOutputDirNotWritable indicates that the output file cannot be created in its directory.
+	ErrOutputDirNotWritable = errors.New("cannot write the output file to its directory")
+
+	// ErrInvalidSecret indicates Kubernetes Secret settings that the Kubernetes API would reject.
+	ErrInvalidSecret = errors.New("invalid Kubernetes Secret")
+
+	// ErrConflictingDuplicates indicates that existing tokens were to be both replaced and kept.
+	ErrConflictingDuplicates = errors.New("replace and allow_duplicate cannot both be set")
+
+	// ErrOfflineWithoutDryRun indicates that offline mode was requested for a token that would be created.
+	ErrOfflineWithoutDryRun = errors.New("--offline requires --dry-run")
+
+	// ErrNoProfile indicates that a profile was to be shown, but none is selected.
+	ErrNoProfile = errors.New("no profile selected (use --profile or profile use)")
+
+	// ErrZoneNotVisible indicates a zone that the master token cannot see.
+	ErrZoneNotVisible = errors.New("zone is not visible to the API token")
+
+	// ErrNoVisibleZones indicates that the master token cannot see any zones to choose from.
+	ErrNoVisibleZones = errors.New("no zones are visible to the API token (use --zone)")
+
+	// ErrInvalidSelection indicates an answer that is not one of the offered choices.
+	ErrInvalidSelection = errors.New("invalid selection")
+
+	// ErrInvalidConfig indicates that validation found problems in the configuration file.
+	ErrInvalidConfig = errors.New("invalid configuration file")
+
+	// ErrRevokeCancelled indicates that the user declined to revoke the matched tokens.
+	ErrRevokeCancelled = errors.New("token revocation cancelled")
+
+	// ErrNotEncrypted indicates that the configuration file to decrypt is not encrypted.
+	ErrNotEncrypted = errors.New("configuration file is not encrypted")
 )
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -36,6 +37,10 @@ var (
 	NewClientFunc = cloudflare.NewClient
 	// GenerateTokenFunc generates a Cloudflare API token, defaulting to cloudflare.GenerateToken.
 	GenerateTokenFunc = cloudflare.GenerateToken
+	// PlanTokenFunc plans a Cloudflare API token without creating it, defaulting to cloudflare.PlanToken.
+	PlanTokenFunc = cloudflare.PlanToken
+	// PreflightFunc checks the master token before use, defaulting to cloudflare.Preflight.
+	PreflightFunc = cloudflare.Preflight
 )
 
 // generateCmd defines the command to generate a new Cloudflare API token.
@@ -43,42 +48,172 @@ var generateCmd = &cobra.Command{
 	Use:   "generate [service name]",
 	Short: "Generate a new Cloudflare API token",
 	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Convert service name to lowercase for consistency.
 		serviceName := strings.ToLower(args[0])
 
-		// Retrieve API token and zone name from configuration.
-		token := viper.GetString("api_token")
-		zoneName := viper.GetString("zone")
+		// Retrieve zone names from configuration.
+		zoneNames := configuredZones()
 
-		// Validate required configuration values.
-		if token == "" {
-			return cloudflare.ErrMissingCredentials
+		// A dry run only builds the token parameters, which can be done offline if the
+		// zone IDs are known.
+		dryRun := viper.GetBool("dry_run")
+		offline := viper.GetBool("offline")
+
+		if offline && !dryRun {
+			return ErrOfflineWithoutDryRun
+		}
+
+		// Retrieve the master API token or Global API Key, which are not needed offline.
+		var creds cloudflare.Credentials
+
+		if !offline {
+			var err error
+
+			creds, err = masterCredentials(cmd)
+			if err != nil {
+				return err
+			}
+		}
+
+		outputFormat := viper.GetString("output")
+
+		err := validateOutputFormat(outputFormat)
+		if err != nil {
+			return err
+		}
+
+		// Validate the credential file format and destination before creating anything.
+		credentialFormat := strings.ToLower(viper.GetString("format"))
+		outPath := viper.GetString("out")
+		force := viper.GetBool("force")
+
+		err = validateCredentialFormat(credentialFormat)
+		if err != nil {
+			return err
+		}
+
+		if credentialFormat != "" && outputFormat != outputText {
+			return ErrConflictingOutput
 		}
 
-		if zoneName == "" {
+		err = checkOutputFile(outPath, force)
+		if err != nil {
+			return err
+		}
+
+		err = checkOutputDir(outPath)
+		if err != nil {
+			return err
+		}
+
+		secret := secretOptions{
+			Name:      viper.GetString("secret_name"),
+			Namespace: viper.GetString("secret_namespace"),
+			Key:       viper.GetString("secret_key"),
+		}
+
+		if outputFormat == outputK8sSecret {
+			err = secret.validate()
+			if err != nil {
+				return err
+			}
+		}
+
+		// Build the optional token settings.
+		opts, err := tokenOptions()
+		if err != nil {
+			return fmt.Errorf("invalid token options: %w", err)
+		}
+
+		if len(zoneNames) == 0 && len(opts.ZoneIDs) == 0 {
 			return ErrMissingConfigZone
 		}
 
-		// Initialize Cloudflare client with the API token.
-		client, err := NewClientFunc(token)
+		// Render the token name template, so that invalid names are rejected before any API calls.
+		opts.Name, err = renderTokenName(serviceName, zoneNames, opts)
 		if err != nil {
-			return fmt.Errorf("failed to initialize Cloudflare client: %w", err)
+			return fmt.Errorf("invalid token options: %w", err)
 		}
 
-		// Create a context for the API call.
+		// Create a context for the API calls.
 		ctx := context.Background()
 
-		// Generate the new API token.
-		newAPIToken, err := GenerateTokenFunc(ctx, serviceName, zoneName, client, client)
+		// Plan the token without API access, translating only IDs and built-in names.
+		if offline {
+			opts.Offline = true
+
+			opts.PermissionGroups, err = cloudflare.ResolvePermissionGroups(opts.PermissionGroups, nil)
+			if err != nil {
+				return fmt.Errorf("invalid token options: %w", err)
+			}
+
+			return planToken(ctx, serviceName, zoneNames, nil, opts)
+		}
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		// Check the master token, so that missing permissions are reported before they
+		// cause a generic API error.
+		err = PreflightFunc(ctx, client, zoneNames, opts.Account).Err()
+		if err != nil {
+			return fmt.Errorf("master token check failed: %w", err)
+		}
+
+		// Translate permission group names to IDs.
+		opts.PermissionGroups, opts.AccountPermissionGroups, err = resolvePermissionGroups(
+			ctx,
+			client,
+			opts.PermissionGroups,
+			opts.AccountID,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid token options: %w", err)
+		}
+
+		if dryRun {
+			return planToken(ctx, serviceName, zoneNames, client, opts)
+		}
+
+		// Generate the new API token. If replacing an existing token fails, the new token
+		// is still written out before reporting the error, so that its value is not lost.
+		newAPIToken, genErr := GenerateTokenFunc(ctx, serviceName, zoneNames, client, client, opts)
+		if genErr != nil && !errors.Is(genErr, cloudflare.ErrReplaceTokenFailed) {
+			return fmt.Errorf("failed to generate token: %w", genErr)
+		}
+
+		// Record the zones in the Secret labels, falling back to the zone IDs.
+		secret.Zone = cloudflare.ZoneListName(zoneNames)
+		if len(zoneNames) == 0 {
+			secret.Zone = cloudflare.ZoneListName(opts.ZoneIDs)
+		}
+
+		// Render the generated token in the requested format.
+		data, err := renderGeneratedToken(outputFormat, credentialFormat, secret, newAPIToken)
 		if err != nil {
-			return fmt.Errorf("failed to generate token: %w", err)
+			return err
 		}
 
-		// Output the generated token.
-		fmt.Fprintln(os.Stdout, newAPIToken)
+		if outPath == "" {
+			_, err = os.Stdout.Write(data)
+			if err != nil {
+				return err
+			}
+		} else {
+			// Write the token to the output file, reporting the path on stderr.
+			err = writeFileAtomic(outPath, data, force)
+			if err != nil {
+				return fmt.Errorf("failed to save token %s: %w", newAPIToken.ID, err)
+			}
 
-		return nil
+			fmt.Fprintf(os.Stderr, "Wrote API token to %s\n", outPath)
+		}
+
+		return genErr
 	},
 }
 
@@ -87,21 +222,282 @@ func init() {
 	// Add the generate command to the root command.
 	rootCmd.AddCommand(generateCmd)
 
-	// Define flags for API token and zone name.
-	generateCmd.Flags().StringP("token", "t", "", "Cloudflare API token")
-	generateCmd.Flags().StringP("zone", "z", "", "Cloudflare zone name")
+	// Define the generate-specific flags.
+	addGenerateFlags()
+}
+
+// addGenerateFlags defines the generate command's flags and binds them to their
+// configuration keys.
+func addGenerateFlags() {
+	// Define the flag for selecting the output format.
+	generateCmd.Flags().StringP(
+		"output",
+		"o",
+		outputText,
+		"Output format: "+strings.Join(outputFormats, ", "),
+	)
+
+	// Define flags for writing the token as a credential file.
+	generateCmd.Flags().String(
+		"format",
+		"",
+		"Credential file format: "+strings.Join(credentialFormatNames(), ", "),
+	)
+	generateCmd.Flags().String("out", "", "Write the token to this file (mode 0600) instead of stdout")
+	generateCmd.Flags().Bool("force", false, "Overwrite the --out file if it already exists")
+
+	// Define flags for the Kubernetes Secret written by --output k8s-secret.
+	generateCmd.Flags().String("secret-name", defaultSecretName, "Name of the Kubernetes Secret")
+	generateCmd.Flags().String("secret-namespace", "", "Namespace of the Kubernetes Secret (default: current namespace)")
+	generateCmd.Flags().String("secret-key", defaultSecretKey, "Kubernetes Secret data key holding the token")
+
+	// Define the flag for customizing the token name.
+	generateCmd.Flags().String(
+		"name-template",
+		"",
+		"Go template for the token name, e.g. '{{.Service}}@{{.Zone}}' (default: service.zone)",
+	)
+
+	// Define flags for previewing the token without creating it.
+	generateCmd.Flags().Bool("dry-run", false, "Print the token parameters as JSON without creating the token")
+	generateCmd.Flags().Bool("offline", false, "With --dry-run, use --zone-id without any API calls")
+
+	// Define flags for handling existing tokens with the same name.
+	generateCmd.Flags().Bool("replace", false, "Delete existing tokens with the same name after creating the new token")
+	generateCmd.Flags().Bool("allow-duplicate", false, "Create the token even if a token with the same name exists")
+	generateCmd.MarkFlagsMutuallyExclusive("replace", "allow-duplicate")
+
+	// Define flags for the token validity window.
+	generateCmd.Flags().String("expires-in", "", "Token lifetime, e.g. 720h or 90d")
+	generateCmd.Flags().String("expires-on", "", "Token expiry time (RFC3339)")
+	generateCmd.Flags().String("not-before", "", "Time before which the token is not valid (RFC3339)")
+	generateCmd.MarkFlagsMutuallyExclusive("expires-in", "expires-on")
 
-	// Bind the token flag to the api_token configuration key.
-	err := viper.BindPFlag("api_token", generateCmd.Flags().Lookup("token"))
+	// Define the flag for giving zone IDs instead of looking them up.
+	generateCmd.Flags().StringSlice(
+		"zone-id",
+		nil,
+		"Cloudflare zone ID (repeatable); skips the zone lookup unless zone names are also given",
+	)
+
+	// Define flags for selecting the permissions granted by the token.
+	generateCmd.Flags().String(
+		"preset",
+		cloudflare.DefaultPreset,
+		"Permission preset: "+strings.Join(cloudflare.PresetNames(nil), ", ")+", or a custom preset",
+	)
+
+	generateCmd.Flags().StringSlice(
+		"permission",
+		nil,
+		"Permission group name or ID to grant instead of a preset (repeatable)",
+	)
+	generateCmd.MarkFlagsMutuallyExclusive("preset", "permission")
+
+	// Define flags for restricting the client IP ranges.
+	generateCmd.Flags().StringSlice("allow-ip", nil, "CIDR the token may be used from (repeatable)")
+	generateCmd.Flags().StringSlice("deny-ip", nil, "CIDR the token may not be used from (repeatable)")
+
+	// Bind the flags to their configuration keys.
+	bindFlag("output", generateCmd.Flags().Lookup("output"))
+	bindFlag("format", generateCmd.Flags().Lookup("format"))
+	bindFlag("out", generateCmd.Flags().Lookup("out"))
+	bindFlag("force", generateCmd.Flags().Lookup("force"))
+	bindFlag("secret_name", generateCmd.Flags().Lookup("secret-name"))
+	bindFlag("secret_namespace", generateCmd.Flags().Lookup("secret-namespace"))
+	bindFlag("secret_key", generateCmd.Flags().Lookup("secret-key"))
+	bindFlag("name_template", generateCmd.Flags().Lookup("name-template"))
+	bindFlag("dry_run", generateCmd.Flags().Lookup("dry-run"))
+	bindFlag("offline", generateCmd.Flags().Lookup("offline"))
+	bindFlag("replace", generateCmd.Flags().Lookup("replace"))
+	bindFlag("allow_duplicate", generateCmd.Flags().Lookup("allow-duplicate"))
+	bindFlag("expires_in", generateCmd.Flags().Lookup("expires-in"))
+	bindFlag("expires_on", generateCmd.Flags().Lookup("expires-on"))
+	bindFlag("not_before", generateCmd.Flags().Lookup("not-before"))
+	bindFlag("zone_id", generateCmd.Flags().Lookup("zone-id"))
+	bindFlag("preset", generateCmd.Flags().Lookup("preset"))
+	bindFlag("permissions", generateCmd.Flags().Lookup("permission"))
+	bindFlag("allow_ip", generateCmd.Flags().Lookup("allow-ip"))
+	bindFlag("deny_ip", generateCmd.Flags().Lookup("deny-ip"))
+}
+
+// selectedPermissionGroups returns the explicitly requested permission groups, or the
+// groups of the selected preset, including custom presets from the configuration.
+func selectedPermissionGroups() ([]string, error) {
+	if groups := viper.GetStringSlice("permissions"); len(groups) > 0 {
+		return groups, nil
+	}
+
+	// Viper lowercases the keys of the presets map, so the name is matched in lowercase.
+	return cloudflare.PresetPermissionGroups(
+		strings.ToLower(viper.GetString("preset")),
+		viper.GetStringMapStringSlice("presets"),
+	)
+}
+
+// tokenOptions builds the optional token settings from the configuration.
+// Permission groups are returned as configured and must be resolved to IDs.
+func tokenOptions() (cloudflare.TokenOptions, error) {
+	// Resolve the expiry from either a lifetime or an absolute time.
+	expiresOn, err := cloudflare.ResolveExpiry(
+		viper.GetString("expires_in"),
+		viper.GetString("expires_on"),
+	)
+	if err != nil {
+		return cloudflare.TokenOptions{}, err
+	}
+
+	// Parse the optional not-before time.
+	notBefore, err := cloudflare.ParseTimestamp(viper.GetString("not_before"))
+	if err != nil {
+		return cloudflare.TokenOptions{}, err
+	}
+
+	// Select the permission groups, which may still be names at this point.
+	permissionGroups, err := selectedPermissionGroups()
+	if err != nil {
+		return cloudflare.TokenOptions{}, err
+	}
+
+	// Select how existing tokens with the same name are handled.
+	duplicates, err := duplicatePolicy()
+	if err != nil {
+		return cloudflare.TokenOptions{}, err
+	}
+
+	// Select the account owning the token, if it is not owned by the user.
+	accountID, err := tokenAccountID()
+	if err != nil {
+		return cloudflare.TokenOptions{}, err
+	}
+
+	return cloudflare.TokenOptions{
+		Duplicates:       duplicates,
+		ExpiresOn:        expiresOn,
+		NotBefore:        notBefore,
+		AllowIPs:         viper.GetStringSlice("allow_ip"),
+		DenyIPs:          viper.GetStringSlice("deny_ip"),
+		ZoneIDs:          viper.GetStringSlice("zone_id"),
+		Account:          viper.GetString("account"),
+		AccountID:        accountID,
+		PermissionGroups: permissionGroups,
+	}, nil
+}
+
+// planToken builds the parameters of the requested token without creating it, and prints
+// them as JSON. A nil client plans the token offline.
+func planToken(
+	ctx context.Context,
+	serviceName string,
+	zoneNames []string,
+	client *cloudflare.Client,
+	opts cloudflare.TokenOptions,
+) error {
+	// Pass a nil interface rather than a nil client, so that no API calls can be made.
+	var api cloudflare.APIInterface
+	if client != nil {
+		api = client
+	}
+
+	plan, err := PlanTokenFunc(ctx, serviceName, zoneNames, client, api, opts)
 	if err != nil {
-		// Panic on binding failure, as it indicates a critical setup error.
-		panic(fmt.Errorf("%w: %w", ErrBindAPITokenFlag, err))
+		return fmt.Errorf("failed to plan token: %w", err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Dry run, not creating API token:", plan.Name)
+
+	return writeTokenPlan(os.Stdout, plan)
+}
+
+// duplicatePolicy returns how existing tokens with the same name are handled, refusing to
+// create duplicates unless replace or allow_duplicate is set.
+func duplicatePolicy() (cloudflare.DuplicatePolicy, error) {
+	replace := viper.GetBool("replace")
+	allowDuplicate := viper.GetBool("allow_duplicate")
+
+	switch {
+	case replace && allowDuplicate:
+		return cloudflare.DuplicateFail, ErrConflictingDuplicates
+	case replace:
+		return cloudflare.DuplicateReplace, nil
+	case allowDuplicate:
+		return cloudflare.DuplicateAllow, nil
+	default:
+		return cloudflare.DuplicateFail, nil
+	}
+}
+
+// renderTokenName renders the configured token name template for a service, returning an
+// empty name when no template is configured. The service name is expected in lowercase,
+// as rotate and revoke match it.
+func renderTokenName(serviceName string, zoneNames []string, opts cloudflare.TokenOptions) (string, error) {
+	text := viper.GetString("name_template")
+	if text == "" {
+		return "", nil
+	}
+
+	// Describe the zones by name, or by ID if no names are given.
+	zoneName := cloudflare.ZoneListName(zoneNames)
+	if len(zoneNames) == 0 {
+		zoneName = cloudflare.ZoneListName(opts.ZoneIDs)
+	}
+
+	return cloudflare.RenderTokenName(
+		text,
+		cloudflare.NewTokenNameData(serviceName, zoneName, opts.Account),
+	)
+}
+
+// serviceTokenMatcher returns a TokenMatcher selecting the tokens generated for the
+// service in the given zones, or in any zone visible to the client if none are given.
+// When a name template is configured, names are matched against the template as
+// generate renders it.
+func serviceTokenMatcher(
+	ctx context.Context,
+	api cloudflare.APIInterface,
+	serviceName string,
+	zoneNames []string,
+) (cloudflare.TokenMatcher, error) {
+	// Describe the zones as generate does, by ID if no names are given.
+	zoneName := cloudflare.ZoneListName(zoneNames)
+	if len(zoneNames) == 0 {
+		zoneName = cloudflare.ZoneListName(viper.GetStringSlice("zone_id"))
+	}
+
+	text := viper.GetString("name_template")
+	if text != "" {
+		return cloudflare.MatchNameTemplate(text, cloudflare.TokenNameData{
+			Service: serviceName,
+			Zone:    zoneName,
+			Account: viper.GetString("account"),
+		})
+	}
+
+	if zoneName == "" {
+		// Only select names ending in known zones, as others may belong to a service whose
+		// name starts with this one, such as "traefik.internal".
+		zones, err := knownZones(ctx, api)
+		if err != nil {
+			return nil, err
+		}
+
+		return cloudflare.MatchServiceZones(serviceName, zones), nil
 	}
 
-	// Bind the zone flag to the zone configuration key.
-	err = viper.BindPFlag("zone", generateCmd.Flags().Lookup("zone"))
+	return cloudflare.MatchTokenName(cloudflare.TokenName(serviceName, zoneName)), nil
+}
+
+// knownZones returns the names and IDs of every zone visible to the client.
+func knownZones(ctx context.Context, api cloudflare.APIInterface) ([]string, error) {
+	visible, err := ListAllZonesFunc(ctx, api)
 	if err != nil {
-		// Panic on binding failure, as it indicates a critical setup error.
-		panic(fmt.Errorf("%w: %w", ErrBindZoneFlag, err))
+		return nil, err
 	}
+
+	zones := make([]string, 0, 2*len(visible))
+	for _, zone := range visible {
+		zones = append(zones, zone.Name, zone.ID)
+	}
+
+	return zones, nil
 }
@@ -22,8 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -41,8 +44,12 @@ func TestGenerateCmd(t *testing.T) {
 		args       []string
 		apiToken   string
 		zone       string
-		clientFunc func(apiToken string) (*cloudflare.Client, error)
-		genFunc    func(ctx context.Context, serviceName, zone string, client *cloudflare.Client, api cloudflare.APIInterface) (string, error)
+		zones      []string
+		presets    map[string][]string
+		clientFunc func(creds cloudflare.Credentials) (*cloudflare.Client, error)
+		genFunc    func(ctx context.Context, serviceName string, zones []string, client *cloudflare.Client, api cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error)
+		planFunc   func(ctx context.Context, serviceName string, zones []string, client *cloudflare.Client, api cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.TokenPlan, error)
+		preflight  cloudflare.PreflightResult
 		configFile string
 		configErr  bool
 		wantErr    bool
@@ -54,14 +61,29 @@ func TestGenerateCmd(t *testing.T) {
 			args:     []string{"generate", "test-service"},
 			apiToken: "valid-token",
 			zone:     "example.com",
-			clientFunc: func(_ string) (*cloudflare.Client, error) {
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
 				return &cloudflare.Client{}, nil
 			},
-			genFunc: func(_ context.Context, _, _ string, _ *cloudflare.Client, _ cloudflare.APIInterface) (string, error) {
-				return newToken, nil
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{Value: newToken}, nil
 			},
 			wantOutput: "new-token\n",
 		},
+		{
+			name:     "PreflightFailure",
+			args:     []string{"generate", "test-service"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			preflight: cloudflare.PreflightResult{
+				{Name: cloudflare.CheckTokenActive},
+				{Name: cloudflare.CheckCreateTokens, Err: cloudflare.ErrMissingTokenPermission},
+			},
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{}, errors.New("token must not be generated")
+			},
+			wantErr:    true,
+			wantErrMsg: "master token check failed: " + cloudflare.ErrMissingTokenPermission.Error(),
+		},
 		{
 			name:       "MissingArgs",
 			args:       []string{"generate"},
@@ -87,7 +109,7 @@ func TestGenerateCmd(t *testing.T) {
 			args:     []string{"generate", "test-service"},
 			apiToken: "valid-token",
 			zone:     "example.com",
-			clientFunc: func(_ string) (*cloudflare.Client, error) {
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
 				return nil, errors.New("client error")
 			},
 			wantErr:    true,
@@ -98,11 +120,11 @@ func TestGenerateCmd(t *testing.T) {
 			args:     []string{"generate", "test-service"},
 			apiToken: "valid-token",
 			zone:     "example.com",
-			clientFunc: func(_ string) (*cloudflare.Client, error) {
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
 				return &cloudflare.Client{}, nil
 			},
-			genFunc: func(_ context.Context, _, _ string, _ *cloudflare.Client, _ cloudflare.APIInterface) (string, error) {
-				return "", errors.New("generate error")
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{}, errors.New("generate error")
 			},
 			wantErr:    true,
 			wantErrMsg: "failed to generate token: generate error",
@@ -120,15 +142,15 @@ func TestGenerateCmd(t *testing.T) {
 			args:     []string{"generate", "test@service#invalid"},
 			apiToken: "valid-token",
 			zone:     "example.com",
-			clientFunc: func(_ string) (*cloudflare.Client, error) {
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
 				return &cloudflare.Client{}, nil
 			},
-			genFunc: func(_ context.Context, serviceName, _ string, _ *cloudflare.Client, _ cloudflare.APIInterface) (string, error) {
+			genFunc: func(_ context.Context, serviceName string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
 				if strings.ContainsAny(serviceName, "@#") {
-					return "", errors.New("invalid service name")
+					return cloudflare.GeneratedToken{}, errors.New("invalid service name")
 				}
 
-				return newToken, nil
+				return cloudflare.GeneratedToken{Value: newToken}, nil
 			},
 			wantErr:    true,
 			wantErrMsg: "failed to generate token: invalid service name",
@@ -138,18 +160,440 @@ func TestGenerateCmd(t *testing.T) {
 			args:     []string{"generate", "test-service", "--token", "flag-token"},
 			apiToken: "config-token",
 			zone:     "example.com",
-			clientFunc: func(token string) (*cloudflare.Client, error) {
-				if token != "flag-token" {
+			clientFunc: func(creds cloudflare.Credentials) (*cloudflare.Client, error) {
+				if creds.APIToken != "flag-token" {
 					return nil, errors.New("expected flag-token")
 				}
 
 				return &cloudflare.Client{}, nil
 			},
-			genFunc: func(_ context.Context, _, _ string, _ *cloudflare.Client, _ cloudflare.APIInterface) (string, error) {
-				return newToken, nil
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "JSONOutput",
+			args:     []string{"generate", "test-service", "--output", "json"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{ID: "token-id", Name: "test-service.example.com", Value: newToken}, nil
+			},
+			wantOutput: "{\n  \"id\": \"token-id\",\n  \"name\": \"test-service.example.com\",\n  \"value\": \"new-token\",\n" +
+				"  \"zone_ids\": null,\n  \"permissions\": null,\n  \"issued_on\": \"0001-01-01T00:00:00Z\"\n}\n",
+		},
+		{
+			name:       "UnsupportedOutput",
+			args:       []string{"generate", "test-service", "-o", "xml"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrUnsupportedOutput.Error(),
+		},
+		{
+			name:     "CertbotFormat",
+			args:     []string{"generate", "certs", "--format", "certbot"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{Name: "certs.example.com", Value: newToken}, nil
+			},
+			wantOutput: "# Cloudflare API token certs.example.com for certbot-dns-cloudflare\n" +
+				"dns_cloudflare_api_token = new-token\n",
+		},
+		{
+			name:       "UnsupportedFormat",
+			args:       []string{"generate", "certs", "--format", "nginx"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrUnsupportedFormat.Error(),
+		},
+		{
+			name:       "FormatWithOutput",
+			args:       []string{"generate", "certs", "--format", "lego", "--output", "json"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrConflictingOutput.Error(),
+		},
+		{
+			name:     "FormatWithTextOutput",
+			args:     []string{"generate", "certs", "-o", "text", "--format", "certbot"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{Name: "certs.example.com", Value: newToken}, nil
+			},
+			wantOutput: "# Cloudflare API token certs.example.com for certbot-dns-cloudflare\n" +
+				"dns_cloudflare_api_token = new-token\n",
+		},
+		{
+			name:     "K8sSecretOutput",
+			args:     []string{"generate", "certs", "-o", "k8s-secret", "--secret-namespace", "cert-manager"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{ID: "token-id", Name: "certs.example.com", Value: newToken}, nil
+			},
+			wantOutput: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: cloudflare-api-token\n  namespace: cert-manager\n" +
+				"  labels:\n    app.kubernetes.io/managed-by: gogeneratecftoken\n    gogeneratecftoken/token-id: token-id\n" +
+				"    gogeneratecftoken/zone: example.com\n  annotations:\n    gogeneratecftoken/token-name: certs.example.com\n" +
+				"type: Opaque\ndata:\n  api-token: bmV3LXRva2Vu\n",
+		},
+		{
+			name:       "OutputDirMissing",
+			args:       []string{"generate", "certs", "--out", filepath.Join(os.TempDir(), "gogeneratecftoken-missing", "cloudflare.ini")},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrOutputDirNotWritable.Error(),
+		},
+		{
+			name:       "K8sSecretInvalidName",
+			args:       []string{"generate", "certs", "-o", "k8s-secret", "--secret-name", "Cloudflare_Token"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrInvalidSecret.Error(),
+		},
+		{
+			name:     "NameTemplate",
+			args:     []string{"generate", "Traefik", "--name-template", "prod-{{.Service}}@ops.{{.Zone}}"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, serviceName string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if serviceName != "traefik" || opts.Name != "prod-traefik@ops.example.com" {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected service %q and name %q", serviceName, opts.Name)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
 			},
 			wantOutput: "new-token\n",
 		},
+		{
+			name:       "InvalidNameTemplate",
+			args:       []string{"generate", "traefik", "--name-template", "{{.Team}}"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrInvalidNameTemplate.Error(),
+		},
+		{
+			name:     "DuplicateFailsByDefault",
+			args:     []string{"generate", "traefik"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if opts.Duplicates != cloudflare.DuplicateFail {
+					return cloudflare.GeneratedToken{Value: newToken}, nil
+				}
+
+				return cloudflare.GeneratedToken{}, cloudflare.ErrTokenAlreadyExists
+			},
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrTokenAlreadyExists.Error(),
+		},
+		{
+			name:     "Replace",
+			args:     []string{"generate", "traefik", "--replace"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if opts.Duplicates != cloudflare.DuplicateReplace {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected duplicate policy %d", opts.Duplicates)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "AllowDuplicate",
+			args:     []string{"generate", "traefik", "--allow-duplicate"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if opts.Duplicates != cloudflare.DuplicateAllow {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected duplicate policy %d", opts.Duplicates)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "ReplaceDeleteError",
+			args:     []string{"generate", "traefik", "--replace"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{Value: newToken}, cloudflare.ErrReplaceTokenFailed
+			},
+			wantErr:    true,
+			wantOutput: "new-token\n",
+			wantErrMsg: cloudflare.ErrReplaceTokenFailed.Error(),
+		},
+		{
+			name:     "DryRun",
+			args:     []string{"generate", "certs", "--dry-run"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				return cloudflare.GeneratedToken{}, errors.New("token created during dry run")
+			},
+			planFunc: func(_ context.Context, _ string, _ []string, client *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.TokenPlan, error) {
+				if client == nil {
+					return cloudflare.TokenPlan{}, errors.New("zone lookup skipped")
+				}
+
+				return cloudflare.TokenPlan{Name: "certs.example.com"}, nil
+			},
+			wantOutput: "{}\n",
+		},
+		{
+			name: "DryRunOffline",
+			args: []string{"generate", "certs", "--dry-run", "--offline", "--zone-id", "023e105f4ecef8ad9ca31a8372d0c353", "--permission", "Cache Purge"},
+			zone: "example.com",
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return nil, errors.New("client created offline")
+			},
+			wantOutput: "{\n  \"name\": \"certs.example.com\",\n  \"policies\": [\n    {\n      \"effect\": \"allow\",\n" +
+				"      \"permission_groups\": [\n        {\n          \"id\": \"e17beae8b8cb423a99b1730f21238bed\"\n        }\n      ],\n" +
+				"      \"resources\": {\n        \"com.cloudflare.api.account.zone.023e105f4ecef8ad9ca31a8372d0c353\": \"*\"\n      }\n    }\n  ]\n}\n",
+		},
+		{
+			name:       "DryRunOfflineWithoutZoneID",
+			args:       []string{"generate", "certs", "--dry-run", "--offline"},
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrOfflineZoneLookup.Error(),
+		},
+		{
+			name:       "OfflineWithoutDryRun",
+			args:       []string{"generate", "certs", "--offline", "--zone-id", "023e105f4ecef8ad9ca31a8372d0c353"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrOfflineWithoutDryRun.Error(),
+		},
+		{
+			name:     "RepeatedZoneFlag",
+			args:     []string{"generate", "certs", "--zone", "Example.com", "-z", "example.org", "--zone", "example.com"},
+			apiToken: "valid-token",
+			zone:     "config.example",
+			genFunc: func(_ context.Context, _ string, zones []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !slices.Equal(zones, []string{"example.com", "example.org"}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected zones %v", zones)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "ZonesList",
+			args:     []string{"generate", "certs"},
+			apiToken: "valid-token",
+			zones:    []string{"example.com", "example.org", "example.net"},
+			genFunc: func(_ context.Context, _ string, zones []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !slices.Equal(zones, []string{"example.com", "example.org", "example.net"}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected zones %v", zones)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "ZoneIDWithoutZone",
+			args:     []string{"generate", "test-service", "--zone-id", "0123456789abcdef0123456789abcdef"},
+			apiToken: "valid-token",
+			genFunc: func(_ context.Context, _ string, zones []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if len(zones) != 0 || !slices.Equal(opts.ZoneIDs, []string{"0123456789abcdef0123456789abcdef"}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected zones %v and zone IDs %v", zones, opts.ZoneIDs)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "AccountFlag",
+			args:     []string{"generate", "test-service", "--account", "Production"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if opts.Account != "Production" {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected account %q", opts.Account)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "ExpiresOn",
+			args:     []string{"generate", "test-service", "--expires-on", "2099-01-02T03:04:05Z"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !opts.ExpiresOn.Equal(time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC)) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected expiry %v", opts.ExpiresOn)
+				}
+
+				if !opts.NotBefore.IsZero() {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected not-before %v", opts.NotBefore)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "ExpiresInWithNotBefore",
+			args:     []string{"generate", "test-service", "--expires-in", "90d", "--not-before", "2099-01-01T00:00:00Z"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if opts.ExpiresOn.IsZero() {
+					return cloudflare.GeneratedToken{}, errors.New("expected an expiry")
+				}
+
+				if !opts.NotBefore.Equal(time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected not-before %v", opts.NotBefore)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name: "IPRestrictions",
+			args: []string{
+				"generate", "test-service",
+				"--allow-ip", "192.0.2.0/24", "--allow-ip", "2001:db8::/32",
+				"--deny-ip", "192.0.2.1/32",
+			},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !slices.Equal(opts.AllowIPs, []string{"192.0.2.0/24", "2001:db8::/32"}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected allow list %v", opts.AllowIPs)
+				}
+
+				if !slices.Equal(opts.DenyIPs, []string{"192.0.2.1/32"}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected deny list %v", opts.DenyIPs)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "DefaultPreset",
+			args:     []string{"generate", "test-service"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				want := []string{cloudflare.ZoneReadPermission, cloudflare.DNSWritePermission}
+				if !slices.Equal(opts.PermissionGroups, want) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected permission groups %v", opts.PermissionGroups)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "CustomPreset",
+			args:     []string{"generate", "ci", "--preset", "ci-purge"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			presets:  map[string][]string{"ci-purge": {"Cache Purge"}},
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !slices.Equal(opts.PermissionGroups, []string{cloudflare.CachePurgePermission}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected permission groups %v", opts.PermissionGroups)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:     "MixedCaseCustomPreset",
+			args:     []string{"generate", "ci", "--preset", "MyPreset"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			presets:  map[string][]string{"MyPreset": {"Cache Purge"}},
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				if !slices.Equal(opts.PermissionGroups, []string{cloudflare.CachePurgePermission}) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected permission groups %v", opts.PermissionGroups)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name: "PermissionNames",
+			args: []string{
+				"generate", "worker",
+				"--permission", "Zone Read", "--permission", "Workers Scripts Write",
+			},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			genFunc: func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, opts cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+				want := []string{cloudflare.ZoneReadPermission, "e086da7e2179491d91ee5f35b3ca210a"}
+				if !slices.Equal(opts.PermissionGroups, want) {
+					return cloudflare.GeneratedToken{}, fmt.Errorf("unexpected permission groups %v", opts.PermissionGroups)
+				}
+
+				return cloudflare.GeneratedToken{Value: newToken}, nil
+			},
+			wantOutput: "new-token\n",
+		},
+		{
+			name:       "UnknownPermission",
+			args:       []string{"generate", "test-service", "--permission", "Everything Admin"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrUnknownPermissionGroup.Error(),
+		},
+		{
+			name:       "UnknownPreset",
+			args:       []string{"generate", "test-service", "--preset", "everything"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrUnknownPreset.Error(),
+		},
+		{
+			name:       "InvalidExpiresIn",
+			args:       []string{"generate", "test-service", "--expires-in", "soon"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrInvalidDuration.Error(),
+		},
+		{
+			name:       "InvalidNotBefore",
+			args:       []string{"generate", "test-service", "--not-before", "tomorrow"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrInvalidTimestamp.Error(),
+		},
+		{
+			name:       "ConflictingExpiryFlags",
+			args:       []string{"generate", "test-service", "--expires-in", "30d", "--expires-on", "2099-01-01T00:00:00Z"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: "if any flags in the group [expires-in expires-on] are set none of the others can be",
+		},
 		{
 			name:       "InvalidConfigFile",
 			args:       []string{"generate", "test-service"},
@@ -181,6 +625,15 @@ func TestGenerateCmd(t *testing.T) {
 
 				v.SetDefault("api_token", tt.apiToken)
 				v.SetDefault("zone", tt.zone)
+				v.SetDefault("zones", tt.zones)
+
+				// Load the presets as from a file, which lowercases their names.
+				presets := make(map[string]any, len(tt.presets))
+				for name, groups := range tt.presets {
+					presets[name] = groups
+				}
+
+				_ = v.MergeConfigMap(map[string]any{"presets": presets})
 			}
 
 			origConfigFile := config.ConfigFile
@@ -191,25 +644,48 @@ func TestGenerateCmd(t *testing.T) {
 
 			origNewClient := NewClientFunc
 			origGenerateToken := GenerateTokenFunc
+			origPlanToken := PlanTokenFunc
+			origPreflight := PreflightFunc
+			origLoadPermissionGroups := LoadPermissionGroupsFunc
 
 			defer func() {
 				NewClientFunc = origNewClient
 				GenerateTokenFunc = origGenerateToken
+				PlanTokenFunc = origPlanToken
+				PreflightFunc = origPreflight
+				LoadPermissionGroupsFunc = origLoadPermissionGroups
 			}()
 
+			PreflightFunc = func(_ context.Context, _ cloudflare.APIInterface, _ []string, _ string) cloudflare.PreflightResult {
+				return tt.preflight
+			}
+
+			LoadPermissionGroupsFunc = func(
+				_ context.Context,
+				_ cloudflare.APIInterface,
+				_ string,
+				_ time.Duration,
+			) ([]cloudflare.PermissionGroup, error) {
+				return testCatalog, nil
+			}
+
 			if tt.clientFunc != nil {
 				NewClientFunc = tt.clientFunc
 			} else {
-				NewClientFunc = func(_ string) (*cloudflare.Client, error) {
+				NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
 					return &cloudflare.Client{}, nil
 				}
 			}
 
+			if tt.planFunc != nil {
+				PlanTokenFunc = tt.planFunc
+			}
+
 			if tt.genFunc != nil {
 				GenerateTokenFunc = tt.genFunc
 			} else {
-				GenerateTokenFunc = func(_ context.Context, _, _ string, _ *cloudflare.Client, _ cloudflare.APIInterface) (string, error) {
-					return newToken, nil
+				GenerateTokenFunc = func(_ context.Context, _ string, _ []string, _ *cloudflare.Client, _ cloudflare.APIInterface, _ cloudflare.TokenOptions) (cloudflare.GeneratedToken, error) {
+					return cloudflare.GeneratedToken{Value: newToken}, nil
 				}
 			}
 
@@ -217,7 +693,9 @@ func TestGenerateCmd(t *testing.T) {
 
 			generateCmd.ResetFlags()
 			generateCmd.Flags().StringP("token", "t", "", "Cloudflare API token")
-			generateCmd.Flags().StringP("zone", "z", "", "Cloudflare zone name")
+			generateCmd.Flags().StringSliceP("zone", "z", nil, "Cloudflare zone name (repeatable)")
+			generateCmd.Flags().StringP("account", "a", "", "Cloudflare account name or ID owning the zones")
+			bindFlag("account", generateCmd.Flags().Lookup("account"))
 
 			err := BindPFlagFunc("api_token", generateCmd.Flags().Lookup("token"))
 			if err != nil {
@@ -229,6 +707,7 @@ func TestGenerateCmd(t *testing.T) {
 				t.Fatalf("Failed to bind zone: %v", err)
 			}
 
+			addGenerateFlags()
 			rootCmd.AddCommand(generateCmd)
 
 			oldStdout := os.Stdout
@@ -250,7 +729,7 @@ func TestGenerateCmd(t *testing.T) {
 				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			if !tt.wantErr && output != tt.wantOutput {
+			if (!tt.wantErr || tt.wantOutput != "") && output != tt.wantOutput {
 				t.Errorf("rootCmd.Execute() output = %q, want %q", output, tt.wantOutput)
 			}
 
@@ -312,7 +791,7 @@ func TestGenerateCmd_BindErrors(t *testing.T) {
 			}
 
 			if tt.flag != "zone" {
-				generateCmd.Flags().StringP("zone", "z", "", "Cloudflare zone name")
+				generateCmd.Flags().StringSliceP("zone", "z", nil, "Cloudflare zone name (repeatable)")
 			}
 
 			rootCmd.AddCommand(generateCmd)
@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// Constants controlling the layout of tabular output.
+const (
+	// tableMinWidth is the minimum cell width of a table.
+	tableMinWidth = 0
+	// tableTabWidth is the tab width of a table.
+	tableTabWidth = 8
+	// tablePadding is the padding added to each cell of a table.
+	tablePadding = 2
+	// emptyCell is printed for table cells without a value.
+	emptyCell = "-"
+)
+
+// ListTokensFunc lists Cloudflare API tokens, defaulting to cloudflare.ListTokens.
+var ListTokensFunc = cloudflare.ListTokens
+
+// listCmd defines the command to list existing Cloudflare API tokens.
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the API tokens owned by the master token",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Retrieve the master API token or Global API Key from its configured source.
+		creds, err := masterCredentials(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		// Create a context for the API call.
+		ctx := context.Background()
+
+		// Retrieve all tokens.
+		tokens, err := ListTokensFunc(ctx, client, client)
+		if err != nil {
+			return fmt.Errorf("failed to list tokens: %w", err)
+		}
+
+		// Output the tokens as a table.
+		return printTokenTable(os.Stdout, tokens)
+	},
+}
+
+// init configures the list command before execution.
+func init() {
+	// Add the list command to the root command.
+	rootCmd.AddCommand(listCmd)
+}
+
+// printTokenTable writes the given tokens to w as an aligned table.
+func printTokenTable(w io.Writer, tokens []cloudflare.TokenSummary) error {
+	table := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	// Write the table header.
+	fmt.Fprintln(table, "ID\tNAME\tSTATUS\tISSUED\tMODIFIED\tEXPIRES\tZONES")
+
+	// Write one row per token.
+	for _, token := range tokens {
+		zones := emptyCell
+		if len(token.Zones) > 0 {
+			zones = strings.Join(token.Zones, ",")
+		}
+
+		fmt.Fprintf(
+			table,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			token.ID,
+			token.Name,
+			token.Status,
+			formatDate(token.IssuedOn),
+			formatDate(token.ModifiedOn),
+			formatDate(token.ExpiresOn),
+			zones,
+		)
+	}
+
+	// Flush the aligned output.
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write token table: %w", err)
+	}
+
+	return nil
+}
+
+// formatDate formats a timestamp for table output, using a placeholder for the zero time.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return emptyCell
+	}
+
+	return t.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+func TestListCmd(t *testing.T) {
+	issued := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		args         []string
+		apiToken     string
+		clientFunc   func(creds cloudflare.Credentials) (*cloudflare.Client, error)
+		listFunc     func(ctx context.Context, client *cloudflare.Client, api cloudflare.APIInterface) ([]cloudflare.TokenSummary, error)
+		wantErr      bool
+		wantErrMsg   string
+		wantContains []string
+	}{
+		{
+			name:     "Success",
+			args:     []string{"list"},
+			apiToken: "valid-token",
+			listFunc: func(_ context.Context, _ *cloudflare.Client, _ cloudflare.APIInterface) ([]cloudflare.TokenSummary, error) {
+				return []cloudflare.TokenSummary{{
+					ID:         "token-id-123",
+					Name:       "service.example.com",
+					Status:     "active",
+					IssuedOn:   issued,
+					ModifiedOn: issued,
+					Zones:      []string{"example.com", "example.org"},
+				}}, nil
+			},
+			wantContains: []string{
+				"ID", "NAME", "STATUS", "ISSUED", "MODIFIED", "EXPIRES", "ZONES",
+				"token-id-123", "service.example.com", "active", "2026-01-02", "example.com,example.org",
+			},
+		},
+		{
+			name:       "MissingAPIToken",
+			args:       []string{"list"},
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "UnexpectedArgs",
+			args:       []string{"list", "extra"},
+			apiToken:   "valid-token",
+			wantErr:    true,
+			wantErrMsg: "unknown command \"extra\"",
+		},
+		{
+			name:     "ClientError",
+			args:     []string{"list"},
+			apiToken: "valid-token",
+			clientFunc: func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return nil, errors.New("client error")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to initialize Cloudflare client: client error",
+		},
+		{
+			name:     "ListError",
+			args:     []string{"list"},
+			apiToken: "valid-token",
+			listFunc: func(_ context.Context, _ *cloudflare.Client, _ cloudflare.APIInterface) ([]cloudflare.TokenSummary, error) {
+				return nil, errors.New("list error")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to list tokens: list error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+			}
+
+			origNewClient := NewClientFunc
+			origListTokens := ListTokensFunc
+
+			defer func() {
+				NewClientFunc = origNewClient
+				ListTokensFunc = origListTokens
+			}()
+
+			NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return &cloudflare.Client{}, nil
+			}
+			if tt.clientFunc != nil {
+				NewClientFunc = tt.clientFunc
+			}
+
+			ListTokensFunc = func(_ context.Context, _ *cloudflare.Client, _ cloudflare.APIInterface) ([]cloudflare.TokenSummary, error) {
+				return nil, nil
+			}
+			if tt.listFunc != nil {
+				ListTokensFunc = tt.listFunc
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.AddCommand(listCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" &&
+				(err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("rootCmd.Execute() output = %q, want it to contain %q", output, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{
+			name: "ZeroTime",
+			want: "-",
+		},
+		{
+			name: "UTCDate",
+			time: time.Date(2026, 5, 7, 12, 0, 0, 0, time.UTC),
+			want: "2026-05-07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDate(tt.time); got != tt.want {
+				t.Errorf("formatDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/atomicfile"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// Constants defining the supported output formats for generated tokens.
+const (
+	// outputText prints only the token value.
+	outputText = "text"
+	// outputJSON prints the token details as JSON.
+	outputJSON = "json"
+	// outputYAML prints the token details as YAML.
+	outputYAML = "yaml"
+	// outputK8sSecret prints a Kubernetes Secret manifest holding the token.
+	outputK8sSecret = "k8s-secret"
+)
+
+// outputFileMode is the permission mode of files written with --out, which hold secrets.
+const outputFileMode = 0o600
+
+// outputFormats lists the supported output formats, in the order shown in help text.
+var outputFormats = []string{outputText, outputJSON, outputYAML, outputK8sSecret}
+
+// validateOutputFormat checks that format is a supported output format.
+func validateOutputFormat(format string) error {
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf(
+			"%w: %q (supported: %s)",
+			ErrUnsupportedOutput,
+			format,
+			strings.Join(outputFormats, ", "),
+		)
+	}
+
+	return nil
+}
+
+// writeGeneratedToken writes a generated token to w in the given output format.
+// The text format writes only the token value, so that it can be captured by scripts.
+func writeGeneratedToken(w io.Writer, format string, token cloudflare.GeneratedToken) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(token)
+		if err != nil {
+			return fmt.Errorf("failed to encode token as JSON: %w", err)
+		}
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+
+		err := encoder.Encode(token)
+		if err != nil {
+			return fmt.Errorf("failed to encode token as YAML: %w", err)
+		}
+
+		err = encoder.Close()
+		if err != nil {
+			return fmt.Errorf("failed to encode token as YAML: %w", err)
+		}
+	case outputText:
+		fmt.Fprintln(w, token.Value)
+	default:
+		return validateOutputFormat(format)
+	}
+
+	return nil
+}
+
+// writeTokenPlan writes the parameters of a planned token to w as indented JSON, in the
+// form sent to the Cloudflare API.
+func writeTokenPlan(w io.Writer, plan cloudflare.TokenPlan) error {
+	data, err := json.MarshalIndent(plan.Params, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token parameters as JSON: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+
+	return err
+}
+
+// renderGeneratedToken renders a generated token in the given credential format, or in
+// the given output format if no credential format is set. The Secret settings are only
+// used by the k8s-secret output format.
+func renderGeneratedToken(
+	output, format string,
+	secret secretOptions,
+	token cloudflare.GeneratedToken,
+) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+
+	switch {
+	case format != "":
+		err = writeCredentials(&buf, format, token)
+	case output == outputK8sSecret:
+		err = writeSecretManifest(&buf, secret, token)
+	default:
+		err = writeGeneratedToken(&buf, output, token)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// checkOutputFile checks that the output file can be written, refusing to overwrite an
+// existing file unless force is set. It is called before the token is generated, so that
+// a token is not created only to be discarded.
+func checkOutputFile(path string, force bool) error {
+	if path == "" || force {
+		return nil
+	}
+
+	_, err := os.Lstat(path)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrOutputFileExists, path)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to check output file: %w", err)
+	}
+
+	return nil
+}
+
+// checkOutputDir checks that the directory of the output file exists and is writable.
+// It is called before the token is generated, so that a token is not created only to be
+// lost.
+func checkOutputDir(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	err := atomicfile.CheckDir(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOutputDirNotWritable, err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to path with owner-only permissions, without ever leaving
+// a partial file. An existing file is only replaced if force is set.
+func writeFileAtomic(path string, data []byte, force bool) error {
+	err := atomicfile.Write(path, data, outputFileMode, force)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrOutputFileExists, path)
+	}
+
+	return err
+}
@@ -0,0 +1,313 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+func TestWriteGeneratedToken(t *testing.T) {
+	token := cloudflare.GeneratedToken{
+		ID:          "token-id-123",
+		Name:        "service.example.com",
+		Value:       "secret-value",
+		ZoneIDs:     []string{"zone-id-123"},
+		Permissions: []string{cloudflare.ZoneReadPermission},
+		ExpiresOn:   time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		IssuedOn:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "Text",
+			format: outputText,
+			want:   "secret-value\n",
+		},
+		{
+			name:   "JSON",
+			format: outputJSON,
+			want: `{
+  "id": "token-id-123",
+  "name": "service.example.com",
+  "value": "secret-value",
+  "zone_ids": [
+    "zone-id-123"
+  ],
+  "permissions": [
+    "c8fed203ed3043cba015a93ad1616f1f"
+  ],
+  "expires_on": "2027-01-01T00:00:00Z",
+  "issued_on": "2026-01-01T00:00:00Z"
+}
+`,
+		},
+		{
+			name:   "YAML",
+			format: outputYAML,
+			want: `id: token-id-123
+name: service.example.com
+value: secret-value
+zone_ids:
+    - zone-id-123
+permissions:
+    - c8fed203ed3043cba015a93ad1616f1f
+expires_on: 2027-01-01T00:00:00Z
+issued_on: 2026-01-01T00:00:00Z
+`,
+		},
+		{
+			name:    "Unsupported",
+			format:  "xml",
+			wantErr: ErrUnsupportedOutput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeGeneratedToken(&buf, tt.format, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("writeGeneratedToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeGeneratedToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOutputDir(t *testing.T) {
+	dir := t.TempDir()
+
+	readOnly := filepath.Join(dir, "read-only")
+
+	err := os.Mkdir(readOnly, 0o500)
+	if err != nil {
+		t.Fatalf("Failed to create read-only directory: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		// skip reports why the case cannot run on this system, if it cannot.
+		skip    string
+		wantErr error
+	}{
+		{
+			name: "NoOutputFile",
+		},
+		{
+			name: "WritableDirectory",
+			path: filepath.Join(dir, "cloudflare.ini"),
+		},
+		{
+			name:    "MissingDirectory",
+			path:    filepath.Join(dir, "missing", "cloudflare.ini"),
+			wantErr: ErrOutputDirNotWritable,
+		},
+		{
+			name:    "ReadOnlyDirectory",
+			path:    filepath.Join(readOnly, "cloudflare.ini"),
+			skip:    readOnlySkipReason(),
+			wantErr: ErrOutputDirNotWritable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skip != "" {
+				t.Skip(tt.skip)
+			}
+
+			err := checkOutputDir(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkOutputDir() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The probe must not leave temporary files behind.
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the read-only directory", len(entries))
+	}
+}
+
+// readOnlySkipReason returns why directory permissions cannot be tested on this system,
+// or an empty string if they can.
+func readOnlySkipReason() string {
+	switch {
+	case runtime.GOOS == "windows":
+		return "directory permissions are not enforced on Windows"
+	case os.Geteuid() == 0:
+		return "directory permissions are not enforced for root"
+	default:
+		return ""
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		force    bool
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "NewFile",
+			want: "new",
+		},
+		{
+			name:     "ExistingFile",
+			existing: "old",
+			want:     "old",
+			wantErr:  ErrOutputFileExists,
+		},
+		{
+			name:     "ExistingFileForced",
+			existing: "old",
+			force:    true,
+			want:     "new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "cloudflare.ini")
+
+			if tt.existing != "" {
+				err := os.WriteFile(path, []byte(tt.existing), 0o644)
+				if err != nil {
+					t.Fatalf("Failed to create existing file: %v", err)
+				}
+			}
+
+			err := checkOutputFile(path, tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkOutputFile() error = %v, want %v", err, tt.wantErr)
+			}
+
+			err = writeFileAtomic(path, []byte("new"), tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("writeFileAtomic() error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read output file: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("output file = %q, want %q", got, tt.want)
+			}
+
+			// Only the written file should remain, without temporary files.
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Errorf("directory has %d entries, want 1", len(entries))
+			}
+
+			if tt.wantErr == nil {
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Fatalf("Failed to stat output file: %v", err)
+				}
+
+				if mode := info.Mode().Perm(); mode != outputFileMode {
+					t.Errorf("output file mode = %o, want %o", mode, outputFileMode)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteTokenPlan(t *testing.T) {
+	plan, err := cloudflare.PlanToken(
+		t.Context(),
+		"certs",
+		[]string{"example.com"},
+		nil,
+		nil,
+		cloudflare.TokenOptions{
+			Offline:   true,
+			ZoneIDs:   []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+			ExpiresOn: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			AllowIPs:  []string{"192.0.2.0/24"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("PlanToken() error = %v", err)
+	}
+
+	want := `{
+  "condition": {
+    "request_ip": {
+      "in": [
+        "192.0.2.0/24"
+      ]
+    }
+  },
+  "expires_on": "2099-01-01T00:00:00Z",
+  "name": "certs.example.com",
+  "policies": [
+    {
+      "effect": "allow",
+      "permission_groups": [
+        {
+          "id": "c8fed203ed3043cba015a93ad1616f1f"
+        },
+        {
+          "id": "4755a26eedb94da69e1066d98aa820be"
+        }
+      ],
+      "resources": {
+        "com.cloudflare.api.account.zone.023e105f4ecef8ad9ca31a8372d0c353": "*"
+      }
+    }
+  ]
+}
+`
+
+	var buf bytes.Buffer
+
+	err = writeTokenPlan(&buf, plan)
+	if err != nil {
+		t.Fatalf("writeTokenPlan() error = %v", err)
+	}
+
+	if got := buf.String(); got != want {
+		t.Errorf("writeTokenPlan() = %q, want %q", got, want)
+	}
+}
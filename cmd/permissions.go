@@ -0,0 +1,196 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+// LoadPermissionGroupsFunc loads the Cloudflare permission group catalog,
+// defaulting to cloudflare.LoadPermissionGroups.
+var LoadPermissionGroupsFunc = cloudflare.LoadPermissionGroups
+
+// permissionsCmd groups the commands for inspecting Cloudflare permission groups.
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Inspect the permission groups that can be granted to tokens",
+}
+
+// permissionsListCmd defines the command to list Cloudflare permission groups.
+var permissionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the permission groups available to API tokens",
+	Long: `List the permission groups available to API tokens.
+
+The catalog is cached in the application directory and refreshed once a day.
+Permission group names can be used in place of IDs in custom presets and with
+the --permission flag of the generate command.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Retrieve the master API token or Global API Key from its configured source.
+		creds, err := masterCredentials(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Read command flags.
+		filter, _ := cmd.Flags().GetString("filter")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		// Load the permission group catalog, bypassing the cache if requested.
+		cacheTTL := cloudflare.PermissionGroupCacheTTL
+		if refresh {
+			cacheTTL = 0
+		}
+
+		groups, err := LoadPermissionGroupsFunc(
+			context.Background(),
+			client,
+			permissionCachePath(client.TokenAccount()),
+			cacheTTL,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to load permission groups: %w", err)
+		}
+
+		// Output the matching permission groups as a table.
+		return printPermissionTable(os.Stdout, cloudflare.FilterPermissionGroups(groups, filter))
+	},
+}
+
+// init configures the permissions commands before execution.
+func init() {
+	// Add the permissions commands to the root command.
+	rootCmd.AddCommand(permissionsCmd)
+	permissionsCmd.AddCommand(permissionsListCmd)
+
+	// Define flags for filtering and refreshing the catalog.
+	permissionsListCmd.Flags().StringP("filter", "f", "", "Only show permission groups whose name contains this text")
+	permissionsListCmd.Flags().Bool("refresh", false, "Fetch the catalog from Cloudflare instead of using the cache")
+}
+
+// permissionCachePath returns the path of the permission group cache file, kept apart
+// for the catalog of each account, as account-owned tokens have a catalog of their own.
+// It returns an empty path, disabling the cache, if the home directory is unknown.
+func permissionCachePath(accountID string) string {
+	appDir, err := config.AppDir()
+	if err != nil {
+		return ""
+	}
+
+	name := cloudflare.PermissionGroupCacheFile
+	if accountID != "" {
+		name = strings.TrimSuffix(name, ".json") + "." + accountID + ".json"
+	}
+
+	return filepath.Join(appDir, name)
+}
+
+// resolvePermissionGroups translates permission group names to IDs, loading the
+// permission group catalog only when a name is not one of the well-known groups.
+// For tokens owned by an account, it also returns the IDs of the groups that apply to
+// accounts, loading the catalog unless every group is a well-known zone group.
+func resolvePermissionGroups(
+	ctx context.Context,
+	api cloudflare.APIInterface,
+	groups []string,
+	accountID string,
+) ([]string, []string, error) {
+	var catalog []cloudflare.PermissionGroup
+
+	loadCatalog := func() error {
+		var err error
+
+		catalog, err = LoadPermissionGroupsFunc(
+			ctx,
+			api,
+			permissionCachePath(accountID),
+			cloudflare.PermissionGroupCacheTTL,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to load permission groups: %w", err)
+		}
+
+		return nil
+	}
+
+	if cloudflare.NeedsPermissionCatalog(groups) {
+		err := loadCatalog()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ids, err := cloudflare.ResolvePermissionGroups(groups, catalog)
+	if err != nil || accountID == "" {
+		return ids, nil, err
+	}
+
+	// Look up the scopes of the groups that are not well-known zone groups.
+	if catalog == nil && cloudflare.NeedsPermissionScopes(ids) {
+		err = loadCatalog()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return ids, cloudflare.AccountScopedPermissionGroups(ids, catalog), nil
+}
+
+// printPermissionTable writes the given permission groups to w as an aligned table.
+func printPermissionTable(w io.Writer, groups []cloudflare.PermissionGroup) error {
+	table := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	// Write the table header.
+	fmt.Fprintln(table, "ID\tNAME\tSCOPES")
+
+	// Write one row per permission group.
+	for _, group := range groups {
+		scopes := emptyCell
+		if len(group.Scopes) > 0 {
+			scopes = strings.Join(group.Scopes, ",")
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\n", group.ID, group.Name, scopes)
+	}
+
+	// Flush the aligned output.
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write permission table: %w", err)
+	}
+
+	return nil
+}
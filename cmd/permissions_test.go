@@ -0,0 +1,294 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+// testCatalog is the permission group catalog returned by the stubbed catalog loader.
+var testCatalog = []cloudflare.PermissionGroup{
+	{ID: "4755a26eedb94da69e1066d98aa820be", Name: "DNS Write", Scopes: []string{"com.cloudflare.api.account.zone"}},
+	{ID: "e086da7e2179491d91ee5f35b3ca210a", Name: "Workers Scripts Write", Scopes: []string{"com.cloudflare.api.account"}},
+	{ID: "c8fed203ed3043cba015a93ad1616f1f", Name: "Zone Read", Scopes: []string{"com.cloudflare.api.account.zone"}},
+}
+
+func TestPermissionsListCmd(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		apiToken        string
+		loadErr         error
+		wantTTL         time.Duration
+		wantErr         bool
+		wantErrMsg      string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:     "Success",
+			args:     []string{"permissions", "list"},
+			apiToken: "valid-token",
+			wantTTL:  cloudflare.PermissionGroupCacheTTL,
+			wantContains: []string{
+				"ID", "NAME", "SCOPES",
+				"4755a26eedb94da69e1066d98aa820be", "DNS Write", "Workers Scripts Write", "Zone Read",
+			},
+		},
+		{
+			name:            "Filter",
+			args:            []string{"permissions", "list", "--filter", "write"},
+			apiToken:        "valid-token",
+			wantTTL:         cloudflare.PermissionGroupCacheTTL,
+			wantContains:    []string{"DNS Write", "Workers Scripts Write"},
+			wantNotContains: []string{"Zone Read"},
+		},
+		{
+			name:         "Refresh",
+			args:         []string{"permissions", "list", "--refresh"},
+			apiToken:     "valid-token",
+			wantTTL:      0,
+			wantContains: []string{"DNS Write"},
+		},
+		{
+			name:       "MissingAPIToken",
+			args:       []string{"permissions", "list"},
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "LoadError",
+			args:       []string{"permissions", "list"},
+			apiToken:   "valid-token",
+			loadErr:    errors.New("api error"),
+			wantErr:    true,
+			wantErrMsg: "failed to load permission groups: api error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+			}
+
+			origNewClient := NewClientFunc
+			origLoadPermissionGroups := LoadPermissionGroupsFunc
+
+			defer func() {
+				NewClientFunc = origNewClient
+				LoadPermissionGroupsFunc = origLoadPermissionGroups
+			}()
+
+			NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return &cloudflare.Client{}, nil
+			}
+
+			var gotTTL time.Duration
+
+			LoadPermissionGroupsFunc = func(
+				_ context.Context,
+				_ cloudflare.APIInterface,
+				_ string,
+				ttl time.Duration,
+			) ([]cloudflare.PermissionGroup, error) {
+				gotTTL = ttl
+
+				return testCatalog, tt.loadErr
+			}
+
+			permissionsListCmd.Flags().VisitAll(func(f *pflag.Flag) {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.AddCommand(permissionsCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" &&
+				(err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if !tt.wantErr && gotTTL != tt.wantTTL {
+				t.Errorf("LoadPermissionGroupsFunc() ttl = %v, want %v", gotTTL, tt.wantTTL)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("rootCmd.Execute() output = %q, want it to contain %q", output, want)
+				}
+			}
+
+			for _, unwanted := range tt.wantNotContains {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("rootCmd.Execute() output = %q, want it not to contain %q", output, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestResolvePermissionGroups(t *testing.T) {
+	const (
+		accountID     = "0123456789abcdef0123456789abcdef"
+		workersWrite  = "e086da7e2179491d91ee5f35b3ca210a"
+		dnsWrite      = "4755a26eedb94da69e1066d98aa820be"
+		workersByName = "Workers Scripts Write"
+	)
+
+	tests := []struct {
+		name        string
+		groups      []string
+		accountID   string
+		loadErr     error
+		wantIDs     []string
+		wantAccount []string
+		wantLoads   int
+		wantErr     bool
+	}{
+		{
+			name:    "UserWellKnown",
+			groups:  []string{"DNS Write"},
+			wantIDs: []string{dnsWrite},
+		},
+		{
+			name:      "UserByName",
+			groups:    []string{workersByName},
+			wantIDs:   []string{workersWrite},
+			wantLoads: 1,
+		},
+		{
+			name:      "AccountWellKnown",
+			groups:    []string{"DNS Write"},
+			accountID: accountID,
+			wantIDs:   []string{dnsWrite},
+		},
+		{
+			name:        "AccountByName",
+			groups:      []string{"DNS Write", workersByName},
+			accountID:   accountID,
+			wantIDs:     []string{dnsWrite, workersWrite},
+			wantAccount: []string{workersWrite},
+			wantLoads:   1,
+		},
+		{
+			name:        "AccountByID",
+			groups:      []string{workersWrite},
+			accountID:   accountID,
+			wantIDs:     []string{workersWrite},
+			wantAccount: []string{workersWrite},
+			wantLoads:   1,
+		},
+		{
+			name:      "AccountLoadError",
+			groups:    []string{workersWrite},
+			accountID: accountID,
+			loadErr:   errors.New("api error"),
+			wantLoads: 1,
+			wantErr:   true,
+		},
+	}
+
+	origLoadPermissionGroups := LoadPermissionGroupsFunc
+
+	defer func() { LoadPermissionGroupsFunc = origLoadPermissionGroups }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loads int
+
+			LoadPermissionGroupsFunc = func(
+				_ context.Context,
+				_ cloudflare.APIInterface,
+				cachePath string,
+				_ time.Duration,
+			) ([]cloudflare.PermissionGroup, error) {
+				loads++
+
+				if tt.accountID != "" && !strings.Contains(cachePath, tt.accountID) {
+					t.Errorf("LoadPermissionGroupsFunc() cache path = %q, want it to contain %q", cachePath, tt.accountID)
+				}
+
+				return testCatalog, tt.loadErr
+			}
+
+			ids, accountGroups, err := resolvePermissionGroups(t.Context(), nil, tt.groups, tt.accountID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolvePermissionGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if loads != tt.wantLoads {
+				t.Errorf("resolvePermissionGroups() loaded the catalog %d times, want %d", loads, tt.wantLoads)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("resolvePermissionGroups() ids = %v, want %v", ids, tt.wantIDs)
+			}
+
+			if !slices.Equal(accountGroups, tt.wantAccount) {
+				t.Errorf("resolvePermissionGroups() account groups = %v, want %v", accountGroups, tt.wantAccount)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+// Constants defining how secrets are masked when settings are shown.
+const (
+	// maskedSecret replaces secrets that are too short to show any part of.
+	maskedSecret = "********"
+	// secretSuffixLength is the number of trailing characters of a secret that are shown.
+	secretSuffixLength = 4
+	// minMaskedLength is the minimum length of a secret whose suffix is shown.
+	minMaskedLength = 12
+	// profileIndent is the indentation of profiles shown as YAML.
+	profileIndent = 2
+)
+
+// secretKeys lists the configuration keys whose values are masked when shown.
+var secretKeys = []string{"api_token", config.APIKeyKey}
+
+// profileCmd groups the commands for managing configuration profiles.
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the named profiles in the configuration file",
+	Long: `Manage the named profiles in the configuration file.
+
+Profiles are listed under the profiles key of the configuration file. The profile
+named by --profile, CF_PROFILE, or the current_profile key is merged over the
+top-level settings before every other command runs.`,
+	// Profile commands read the configuration file as-is, so that an unknown
+	// current_profile can still be listed and replaced.
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+}
+
+// profileListCmd defines the command to list the configured profiles.
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured profiles, marking the active one",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		names := config.ProfileNames(viper.GetViper())
+		if len(names) == 0 {
+			fmt.Fprintln(os.Stderr, "No profiles configured.")
+
+			return nil
+		}
+
+		active := config.ActiveProfile(viper.GetViper())
+
+		for _, name := range names {
+			marker := " "
+			if name == active {
+				marker = "*"
+			}
+
+			fmt.Fprintf(os.Stdout, "%s %s\n", marker, name)
+		}
+
+		return nil
+	},
+}
+
+// profileUseCmd defines the command to select the default profile.
+var profileUseCmd = &cobra.Command{
+	Use:   "use [profile]",
+	Short: "Set the current_profile in the configuration file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+
+		// Only select profiles that exist.
+		_, err := config.Profile(viper.GetViper(), name)
+		if err != nil {
+			return err
+		}
+
+		err = config.SetFileValue(viper.ConfigFileUsed(), config.CurrentProfileKey, name)
+		if err != nil {
+			return fmt.Errorf("failed to set current profile: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Switched to profile %s.\n", name)
+
+		return nil
+	},
+}
+
+// profileShowCmd defines the command to show the settings of a profile.
+var profileShowCmd = &cobra.Command{
+	Use:   "show [profile]",
+	Short: "Show the settings of a profile, defaulting to the active one",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		name := config.ActiveProfile(viper.GetViper())
+		if len(args) > 0 {
+			name = strings.ToLower(args[0])
+		}
+
+		if name == "" {
+			return ErrNoProfile
+		}
+
+		settings, err := config.Profile(viper.GetViper(), name)
+		if err != nil {
+			return err
+		}
+
+		return writeProfile(os.Stdout, name, settings)
+	},
+}
+
+// init configures the profile commands before execution.
+func init() {
+	// Add the profile commands to the root command.
+	profileCmd.AddCommand(profileListCmd, profileUseCmd, profileShowCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// writeProfile writes the settings of a profile to w as YAML, masking secrets.
+func writeProfile(w io.Writer, name string, settings map[string]any) error {
+	masked := maps.Clone(settings)
+
+	for key, value := range masked {
+		if secret, ok := value.(string); ok && slices.Contains(secretKeys, key) {
+			masked[key] = maskSecret(secret)
+		}
+	}
+
+	fmt.Fprintf(w, "# Profile: %s\n", name)
+
+	if len(masked) == 0 {
+		fmt.Fprintln(w, "# (inherits all top-level settings)")
+
+		return nil
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(profileIndent)
+
+	err := encoder.Encode(masked)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	return nil
+}
+
+// maskSecret hides a secret, showing only its last characters when it is long enough
+// for them not to give it away.
+func maskSecret(secret string) string {
+	if len(secret) < minMaskedLength {
+		return maskedSecret
+	}
+
+	return maskedSecret + secret[len(secret)-secretSuffixLength:]
+}
@@ -0,0 +1,248 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+const testProfileConfig = `api_token: shared-token-value
+zone: example.com
+current_profile: staging
+profiles:
+  staging:
+    api_token: staging-token-1234
+    zone: staging.example.com
+  production:
+    account: Production
+    zones:
+      - example.com
+      - example.org
+`
+
+func TestProfileCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		config     string
+		profile    string
+		wantOutput string
+		wantConfig string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "List",
+			args:       []string{"profile", "list"},
+			config:     testProfileConfig,
+			wantOutput: "  production\n* staging\n",
+		},
+		{
+			name:       "ListSelectedProfile",
+			args:       []string{"profile", "list"},
+			config:     testProfileConfig,
+			profile:    "production",
+			wantOutput: "* production\n  staging\n",
+		},
+		{
+			name:       "ListWithoutProfiles",
+			args:       []string{"profile", "list"},
+			config:     "api_token: token\n",
+			wantOutput: "",
+		},
+		{
+			name:       "ShowActive",
+			args:       []string{"profile", "show"},
+			config:     testProfileConfig,
+			wantOutput: "# Profile: staging\napi_token: '********1234'\nzone: staging.example.com\n",
+		},
+		{
+			name:       "ShowNamed",
+			args:       []string{"profile", "show", "Production"},
+			config:     testProfileConfig,
+			wantOutput: "# Profile: production\naccount: Production\nzones:\n  - example.com\n  - example.org\n",
+		},
+		{
+			name:       "ShowWithoutProfile",
+			args:       []string{"profile", "show"},
+			config:     "api_token: token\n",
+			wantErr:    true,
+			wantErrMsg: ErrNoProfile.Error(),
+		},
+		{
+			name:       "ShowUnknown",
+			args:       []string{"profile", "show", "missing"},
+			config:     testProfileConfig,
+			wantErr:    true,
+			wantErrMsg: config.ErrUnknownProfile.Error(),
+		},
+		{
+			name:       "Use",
+			args:       []string{"profile", "use", "production"},
+			config:     testProfileConfig,
+			wantConfig: strings.Replace(testProfileConfig, "current_profile: staging", "current_profile: production", 1),
+		},
+		{
+			name:       "UseUnknown",
+			args:       []string{"profile", "use", "missing"},
+			config:     testProfileConfig,
+			wantConfig: testProfileConfig,
+			wantErr:    true,
+			wantErrMsg: config.ErrUnknownProfile.Error(),
+		},
+		{
+			name:       "UseFixesUnknownCurrentProfile",
+			args:       []string{"profile", "use", "staging"},
+			config:     strings.Replace(testProfileConfig, "current_profile: staging", "current_profile: deleted", 1),
+			wantConfig: testProfileConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.config), 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetConfigFile(path)
+
+				err := v.ReadInConfig()
+				if err != nil {
+					t.Errorf("Failed to read config: %v", err)
+				}
+			}
+
+			if tt.profile != "" {
+				viper.Set(config.ProfileKey, tt.profile)
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken", PersistentPreRunE: applyProfile}
+			rootCmd.AddCommand(profileCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err = rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if output := buf.String(); output != tt.wantOutput {
+				t.Errorf("rootCmd.Execute() output = %q, want %q", output, tt.wantOutput)
+			}
+
+			if tt.wantConfig != "" {
+				got, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("Failed to read config: %v", err)
+				}
+
+				if string(got) != tt.wantConfig {
+					t.Errorf("config file = %q, want %q", got, tt.wantConfig)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	viper.Reset()
+
+	defer viper.Reset()
+
+	viper.SetConfigType(config.ConfigExt)
+
+	err := viper.ReadConfig(strings.NewReader(testProfileConfig))
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	// Flags and environment variables take precedence over the profile.
+	t.Setenv("CF_PROFILE", "production")
+	viper.SetEnvPrefix("CF")
+	viper.AutomaticEnv()
+
+	err = applyProfile(nil, nil)
+	if err != nil {
+		t.Fatalf("applyProfile() error = %v", err)
+	}
+
+	if got := configuredZones(); strings.Join(got, ",") != "example.com,example.org" {
+		t.Errorf("configuredZones() = %v, want the production zones", got)
+	}
+
+	if got := viper.GetString("account"); got != "Production" {
+		t.Errorf("account = %q, want %q", got, "Production")
+	}
+
+	if got := viper.GetString("api_token"); got != "shared-token-value" {
+		t.Errorf("api_token = %q, want the inherited top-level token", got)
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		secret string
+		want   string
+	}{
+		{secret: "", want: "********"},
+		{secret: "short", want: "********"},
+		{secret: "8M7wS6hCpXVc-DoRnPPY_UCWPgy8aea4Wy6kCe5T", want: "********Ce5T"},
+	}
+
+	for _, tt := range tests {
+		if got := maskSecret(tt.secret); got != tt.want {
+			t.Errorf("maskSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+		}
+	}
+}
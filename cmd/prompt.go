@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// stdin is the source of interactive input.
+// It defaults to os.Stdin but can be overridden for testing.
+var stdin io.Reader = os.Stdin
+
+// confirm asks a yes/no question on stderr and reports whether the user answered yes.
+// Any answer other than "y" or "yes" is treated as no.
+func confirm(question string) (bool, error) {
+	// Print the question to stderr to keep stdout clean for command output.
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	// Read a single line of input.
+	answer, err := readLine()
+	if err != nil {
+		return false, err
+	}
+
+	// Accept only an explicit yes.
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// prompt asks a question on stderr and returns the answer, trimmed of surrounding whitespace.
+func prompt(question string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", question)
+
+	answer, err := readLine()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
+// promptSecret asks for a secret on stderr without echoing the answer when stdin is a
+// terminal. Input that is not a terminal, such as a pipe, is read as a plain line.
+func promptSecret(question string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", question)
+
+	file, ok := stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		answer, err := readLine()
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(answer), nil
+	}
+
+	secret, err := term.ReadPassword(int(file.Fd()))
+
+	// End the prompt line, as the newline typed by the user is not echoed.
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrReadInput, err)
+	}
+
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// readLine reads a single line from stdin, without the trailing newline.
+// It reads one byte at a time rather than buffering, so that input following the line
+// is left for the next prompt.
+func readLine() (string, error) {
+	var line strings.Builder
+
+	buf := make([]byte, 1)
+
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+
+			line.WriteByte(buf[0])
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrReadInput, err)
+		}
+	}
+
+	return strings.TrimSuffix(line.String(), "\r"), nil
+}
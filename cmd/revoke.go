@@ -0,0 +1,149 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+var (
+	// FindTokensFunc finds Cloudflare API tokens, defaulting to cloudflare.FindTokens.
+	FindTokensFunc = cloudflare.FindTokens
+	// RevokeTokenFunc deletes a Cloudflare API token, defaulting to cloudflare.RevokeToken.
+	RevokeTokenFunc = cloudflare.RevokeToken
+)
+
+// revokeCmd defines the command to revoke existing Cloudflare API tokens.
+var revokeCmd = &cobra.Command{
+	Use:   "revoke [service name]",
+	Short: "Revoke Cloudflare API tokens by service name, token name, or ID",
+	Long: `Revoke Cloudflare API tokens by service name, token name, or ID.
+
+By default the argument is a service name. When zones are configured, the token
+generated for the service in those zones is revoked; otherwise every token named
+"service.<zone>" is revoked, for any zone visible to the master token. Tokens are named by the name_template setting if it is set.
+Use --name to match a full token name or --id to match a token ID.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Retrieve the master API token or Global API Key from its configured source.
+		creds, err := masterCredentials(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Retrieve the zone names from configuration.
+		zoneNames := configuredZones()
+
+		// Read command flags.
+		byID, _ := cmd.Flags().GetBool("id")
+		byName, _ := cmd.Flags().GetBool("name")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		// Create a context for the API calls.
+		ctx := context.Background()
+
+		// Resolve the argument to the matching tokens.
+		matcher, err := revokeMatcher(ctx, client, args[0], zoneNames, byID, byName)
+		if err != nil {
+			return err
+		}
+
+		tokens, err := FindTokensFunc(ctx, client, matcher)
+		if err != nil {
+			return fmt.Errorf("failed to find tokens: %w", err)
+		}
+
+		if len(tokens) == 0 {
+			return fmt.Errorf("%w: %s", cloudflare.ErrTokenNotFound, args[0])
+		}
+
+		// Show the tokens that will be revoked.
+		fmt.Fprintln(os.Stdout, "The following API tokens will be revoked:")
+
+		for _, t := range tokens {
+			fmt.Fprintf(os.Stdout, "  %s (%s)\n", t.Name, t.ID)
+		}
+
+		// Ask for confirmation unless skipped.
+		if !skipConfirm {
+			confirmed, err := confirm(fmt.Sprintf("Revoke %d token(s)?", len(tokens)))
+			if err != nil {
+				return err
+			}
+
+			if !confirmed {
+				return ErrRevokeCancelled
+			}
+		}
+
+		// Revoke each matching token.
+		for _, t := range tokens {
+			err := RevokeTokenFunc(ctx, client, t.ID)
+			if err != nil {
+				return fmt.Errorf("failed to revoke token %s: %w", t.Name, err)
+			}
+
+			fmt.Fprintf(os.Stdout, "Revoked API token: %s (%s)\n", t.Name, t.ID)
+		}
+
+		return nil
+	},
+}
+
+// init configures the revoke command before execution.
+func init() {
+	// Add the revoke command to the root command.
+	rootCmd.AddCommand(revokeCmd)
+
+	// Define flags for selecting tokens and skipping confirmation.
+	revokeCmd.Flags().Bool("id", false, "Treat the argument as a token ID")
+	revokeCmd.Flags().Bool("name", false, "Treat the argument as a full token name")
+	revokeCmd.Flags().BoolP("yes", "y", false, "Revoke without asking for confirmation")
+	revokeCmd.MarkFlagsMutuallyExclusive("id", "name")
+}
+
+// revokeMatcher selects how the revoke argument is matched against existing tokens.
+func revokeMatcher(
+	ctx context.Context,
+	api cloudflare.APIInterface,
+	arg string,
+	zoneNames []string,
+	byID, byName bool,
+) (cloudflare.TokenMatcher, error) {
+	switch {
+	case byID:
+		return cloudflare.MatchTokenID(arg), nil
+	case byName:
+		return cloudflare.MatchTokenName(arg), nil
+	default:
+		return serviceTokenMatcher(ctx, api, strings.ToLower(arg), zoneNames)
+	}
+}
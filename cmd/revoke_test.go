@@ -0,0 +1,279 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+func TestRevokeCmd(t *testing.T) {
+	existing := []shared.Token{
+		{ID: "token-1", Name: "traefik.example.com"},
+		{ID: "token-2", Name: "traefik.example.org"},
+		{ID: "token-3", Name: "caddy.example.com"},
+		{ID: "token-4", Name: "prod-traefik@ci.example.com"},
+		{ID: "token-5", Name: "prod-traefik@ops.example.org"},
+		{ID: "token-6", Name: "traefik.internal.example.com"},
+	}
+
+	visible := []cloudflare.ZoneSummary{
+		{ID: "zone-1", Name: "example.com"},
+		{ID: "zone-2", Name: "example.org"},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		apiToken    string
+		zone        string
+		template    string
+		input       string
+		revokeErr   error
+		listErr     error
+		wantRevoked []string
+		wantErr     bool
+		wantErrMsg  string
+	}{
+		{
+			name:        "ServiceInZoneWithConfirmation",
+			args:        []string{"revoke", "Traefik"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			input:       "y\n",
+			wantRevoked: []string{"token-1"},
+		},
+		{
+			name:        "ServicePrefixWithoutZone",
+			args:        []string{"revoke", "traefik", "--yes"},
+			apiToken:    "valid-token",
+			wantRevoked: []string{"token-1", "token-2"},
+		},
+		{
+			name:        "DottedServiceWithoutZone",
+			args:        []string{"revoke", "traefik.internal", "--yes"},
+			apiToken:    "valid-token",
+			wantRevoked: []string{"token-6"},
+		},
+		{
+			name:       "ListZonesError",
+			args:       []string{"revoke", "traefik", "--yes"},
+			apiToken:   "valid-token",
+			listErr:    cloudflare.ErrListZonesFailed,
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrListZonesFailed.Error(),
+		},
+		{
+			name:        "NameTemplateInZone",
+			args:        []string{"revoke", "traefik", "--yes"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			template:    "prod-{{.Service}}@{{.User}}.{{.Zone}}",
+			wantRevoked: []string{"token-4"},
+		},
+		{
+			name:        "MixedCaseNameTemplate",
+			args:        []string{"revoke", "Traefik", "--yes"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			template:    "prod-{{.Service}}@{{.User}}.{{.Zone}}",
+			wantRevoked: []string{"token-4"},
+		},
+		{
+			name:        "NameTemplateWithoutZone",
+			args:        []string{"revoke", "traefik", "--yes"},
+			apiToken:    "valid-token",
+			template:    "prod-{{.Service}}@{{.User}}.{{.Zone}}",
+			wantRevoked: []string{"token-4", "token-5"},
+		},
+		{
+			name:        "ByName",
+			args:        []string{"revoke", "caddy.example.com", "--name", "-y"},
+			apiToken:    "valid-token",
+			zone:        "example.org",
+			wantRevoked: []string{"token-3"},
+		},
+		{
+			name:        "ByID",
+			args:        []string{"revoke", "token-2", "--id", "-y"},
+			apiToken:    "valid-token",
+			wantRevoked: []string{"token-2"},
+		},
+		{
+			name:       "Declined",
+			args:       []string{"revoke", "traefik"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			input:      "n\n",
+			wantErr:    true,
+			wantErrMsg: ErrRevokeCancelled.Error(),
+		},
+		{
+			name:       "NoInput",
+			args:       []string{"revoke", "traefik"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: ErrRevokeCancelled.Error(),
+		},
+		{
+			name:       "NotFound",
+			args:       []string{"revoke", "certbot", "-y"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrTokenNotFound.Error(),
+		},
+		{
+			name:       "MissingAPIToken",
+			args:       []string{"revoke", "traefik"},
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "ConflictingFlags",
+			args:       []string{"revoke", "traefik", "--id", "--name"},
+			apiToken:   "valid-token",
+			wantErr:    true,
+			wantErrMsg: "none of the others can be",
+		},
+		{
+			name:       "RevokeError",
+			args:       []string{"revoke", "traefik", "-y"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			revokeErr:  errors.New("delete error"),
+			wantErr:    true,
+			wantErrMsg: "failed to revoke token traefik.example.com: delete error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+				v.SetDefault("zone", tt.zone)
+				v.SetDefault("name_template", tt.template)
+			}
+
+			origNewClient := NewClientFunc
+			origFindTokens := FindTokensFunc
+			origRevokeToken := RevokeTokenFunc
+			origListAllZones := ListAllZonesFunc
+			origStdin := stdin
+
+			defer func() {
+				NewClientFunc = origNewClient
+				FindTokensFunc = origFindTokens
+				RevokeTokenFunc = origRevokeToken
+				ListAllZonesFunc = origListAllZones
+				stdin = origStdin
+			}()
+
+			ListAllZonesFunc = func(_ context.Context, _ cloudflare.APIInterface) ([]cloudflare.ZoneSummary, error) {
+				return visible, tt.listErr
+			}
+
+			NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return &cloudflare.Client{}, nil
+			}
+
+			FindTokensFunc = func(_ context.Context, _ cloudflare.APIInterface, match cloudflare.TokenMatcher) ([]shared.Token, error) {
+				var matches []shared.Token
+
+				for _, token := range existing {
+					if match(token) {
+						matches = append(matches, token)
+					}
+				}
+
+				return matches, nil
+			}
+
+			var revoked []string
+
+			RevokeTokenFunc = func(_ context.Context, _ cloudflare.APIInterface, tokenID string) error {
+				if tt.revokeErr != nil {
+					return tt.revokeErr
+				}
+
+				revoked = append(revoked, tokenID)
+
+				return nil
+			}
+
+			stdin = strings.NewReader(tt.input)
+
+			revokeCmd.Flags().VisitAll(func(f *pflag.Flag) {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.AddCommand(revokeCmd)
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stdout, os.Stderr = w, w
+
+			defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" &&
+				(err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+
+			if !tt.wantErr && !slices.Equal(revoked, tt.wantRevoked) {
+				t.Errorf("revoked tokens = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -19,12 +19,18 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
 	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
 )
 
@@ -68,9 +74,10 @@ Example:
 
 // rootCmd defines the root command for the CLI tool.
 var rootCmd = &cobra.Command{
-	Use:   "goGenerateCFToken",
-	Short: shortDescription,
-	Long:  longDescription,
+	Use:               "goGenerateCFToken",
+	Short:             shortDescription,
+	Long:              longDescription,
+	PersistentPreRunE: applyProfile,
 }
 
 // Execute runs the root command, handling errors by exiting with a non-zero status.
@@ -100,6 +107,173 @@ func init() {
 		"",
 		configFilePath,
 	)
+
+	// Define persistent flags for API token, zone names, and account, shared by all commands.
+	rootCmd.PersistentFlags().StringP("token", "t", "", "Cloudflare API token")
+	rootCmd.PersistentFlags().Bool("token-stdin", false, "Read the Cloudflare API token from stdin")
+	rootCmd.PersistentFlags().StringSliceP("zone", "z", nil, "Cloudflare zone name (repeatable)")
+	rootCmd.PersistentFlags().StringP("account", "a", "", "Cloudflare account name or ID owning the zones")
+	rootCmd.PersistentFlags().String("profile", "", "Configuration profile to use (default: current_profile)")
+	rootCmd.PersistentFlags().String("owner", "", "Owner of the managed tokens: user or account (default: user)")
+
+	// Bind the token flag to the api_token configuration key.
+	err := viper.BindPFlag("api_token", rootCmd.PersistentFlags().Lookup("token"))
+	if err != nil {
+		// Panic on binding failure, as it indicates a critical setup error.
+		panic(fmt.Errorf("%w: %w", ErrBindAPITokenFlag, err))
+	}
+
+	rootCmd.MarkFlagsMutuallyExclusive("token", "token-stdin")
+
+	// Bind the zone flag to the zone configuration key.
+	err = viper.BindPFlag("zone", rootCmd.PersistentFlags().Lookup("zone"))
+	if err != nil {
+		// Panic on binding failure, as it indicates a critical setup error.
+		panic(fmt.Errorf("%w: %w", ErrBindZoneFlag, err))
+	}
+
+	// Bind the account flag to the account configuration key.
+	bindFlag("account", rootCmd.PersistentFlags().Lookup("account"))
+
+	// Bind the owner flag to the owner configuration key.
+	bindFlag("owner", rootCmd.PersistentFlags().Lookup("owner"))
+
+	// Bind the profile flag to the profile configuration key, also set by CF_PROFILE.
+	bindFlag(config.ProfileKey, rootCmd.PersistentFlags().Lookup("profile"))
+}
+
+// applyProfile merges the active profile into the configuration before a command runs.
+// It fails if the configuration file could not be loaded, such as when it cannot be
+// decrypted, as the settings read from it are unusable.
+func applyProfile(_ *cobra.Command, _ []string) error {
+	err := config.LoadError()
+	if err != nil {
+		return err
+	}
+
+	name, err := config.ApplyProfile(viper.GetViper())
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		fmt.Fprintf(os.Stderr, "Using profile: %s\n", name)
+	}
+
+	return nil
+}
+
+// masterCredentials returns the credentials to authenticate with: the master API token
+// from configuredToken, or else the Global API Key from the api_key and api_email
+// settings, or else the token stored by auth login. It returns an error if there are
+// none, or if the Global API Key is incomplete.
+func masterCredentials(cmd *cobra.Command) (cloudflare.Credentials, error) {
+	token, err := configuredToken(cmd)
+	if err != nil {
+		return cloudflare.Credentials{}, err
+	}
+
+	creds := cloudflare.Credentials{
+		APIToken: token,
+		APIKey:   viper.GetString(config.APIKeyKey),
+		APIEmail: viper.GetString(config.APIEmailKey),
+	}
+
+	// Fall back to the stored token only if nothing is configured.
+	if creds.APIToken == "" && creds.APIKey == "" && creds.APIEmail == "" {
+		creds.APIToken, err = storedToken()
+		if err != nil {
+			return cloudflare.Credentials{}, err
+		}
+	}
+
+	return creds, creds.Validate()
+}
+
+// newTokenClient creates a Cloudflare client for the credentials that manages the tokens
+// of the configured owner: the user, or the account given by its ID with --account.
+func newTokenClient(creds cloudflare.Credentials) (*cloudflare.Client, error) {
+	accountID, err := tokenAccountID()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClientFunc(creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Cloudflare client: %w", err)
+	}
+
+	client.SetTokenAccount(accountID)
+
+	return client, nil
+}
+
+// tokenAccountID returns the ID of the account owning the managed tokens if the owner
+// setting is account, or an empty ID for tokens owned by the user.
+func tokenAccountID() (string, error) {
+	owner, err := cloudflare.ParseTokenOwner(viper.GetString("owner"))
+	if err != nil {
+		return "", err
+	}
+
+	return cloudflare.TokenAccountID(owner, viper.GetString("account"))
+}
+
+// configuredToken returns the master API token, read from stdin if --token-stdin is set,
+// given by --token, or otherwise from the api_token, api_token_file, or api_token_command
+// settings. It returns an empty token if none of them is set.
+func configuredToken(cmd *cobra.Command) (string, error) {
+	fromStdin, _ := cmd.Flags().GetBool("token-stdin")
+	if !fromStdin {
+		// A token given on the command line replaces every configured source.
+		if flag := cmd.Flags().Lookup("token"); flag != nil && flag.Changed {
+			return flag.Value.String(), nil
+		}
+
+		return config.ResolveAPIToken(cmd.Context(), viper.GetViper())
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrReadInput, err)
+	}
+
+	token := config.TrimToken(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%w: nothing was read from stdin", config.ErrEmptyToken)
+	}
+
+	return token, nil
+}
+
+// configuredZones returns the configured zone names, lowercased and without duplicates.
+// Zones given with --zone, CF_ZONE, or the zone key take precedence over the zones list
+// in the configuration file.
+func configuredZones() []string {
+	zones := viper.GetStringSlice("zone")
+	if len(zones) == 0 {
+		zones = viper.GetStringSlice("zones")
+	}
+
+	names := make([]string, 0, len(zones))
+
+	for _, zone := range zones {
+		zone = strings.ToLower(strings.TrimSpace(zone))
+		if zone != "" && !slices.Contains(names, zone) {
+			names = append(names, zone)
+		}
+	}
+
+	return names
+}
+
+// bindFlag binds a command flag to a configuration key.
+// It panics on failure, as a binding error indicates a critical setup error.
+func bindFlag(key string, flag *pflag.Flag) {
+	err := BindPFlagFunc(key, flag)
+	if err != nil {
+		panic(fmt.Errorf("%w %q: %w", ErrBindFlag, key, err))
+	}
 }
 
 // userHomeDir returns the user’s home directory based on the operating system.
@@ -18,11 +18,15 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
 	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
 )
 
@@ -183,3 +187,172 @@ func TestRootCmd_ConfigFlag(t *testing.T) {
 		)
 	}
 }
+
+func TestMasterCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		apiToken  string
+		tokenFile string
+		apiKey    string
+		apiEmail  string
+		input     string
+		wantCreds cloudflare.Credentials
+		wantErr   error
+	}{
+		{
+			name:      "FromConfig",
+			apiToken:  "config-token",
+			wantCreds: cloudflare.Credentials{APIToken: "config-token"},
+		},
+		{
+			name:      "FromStdin",
+			args:      []string{"--token-stdin"},
+			apiToken:  "config-token",
+			input:     "stdin-token\n",
+			wantCreds: cloudflare.Credentials{APIToken: "stdin-token"},
+		},
+		{
+			name:      "FlagReplacesTokenFile",
+			args:      []string{"--token", "flag-token"},
+			tokenFile: filepath.Join(t.TempDir(), "missing"),
+			wantCreds: cloudflare.Credentials{APIToken: "flag-token"},
+		},
+		{
+			name:    "EmptyStdin",
+			args:    []string{"--token-stdin"},
+			input:   "\n",
+			wantErr: config.ErrEmptyToken,
+		},
+		{
+			name:      "GlobalAPIKey",
+			apiKey:    "global-key",
+			apiEmail:  "user@example.com",
+			wantCreds: cloudflare.Credentials{APIKey: "global-key", APIEmail: "user@example.com"},
+		},
+		{
+			name:      "TokenAndGlobalAPIKey",
+			apiToken:  "config-token",
+			apiKey:    "global-key",
+			apiEmail:  "user@example.com",
+			wantCreds: cloudflare.Credentials{APIToken: "config-token", APIKey: "global-key", APIEmail: "user@example.com"},
+		},
+		{
+			name:    "GlobalAPIKeyWithoutEmail",
+			apiKey:  "global-key",
+			wantErr: cloudflare.ErrIncompleteGlobalAPIKey,
+		},
+		{
+			name:     "EmailWithoutGlobalAPIKey",
+			apiEmail: "user@example.com",
+			wantErr:  cloudflare.ErrIncompleteGlobalAPIKey,
+		},
+		{
+			name:    "NoCredentials",
+			wantErr: cloudflare.ErrMissingCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("api_token", tt.apiToken)
+			viper.Set("api_token_file", tt.tokenFile)
+			viper.Set("api_key", tt.apiKey)
+			viper.Set("api_email", tt.apiEmail)
+
+			origStdin := stdin
+
+			defer func() { stdin = origStdin }()
+
+			stdin = strings.NewReader(tt.input)
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().String("token", "", "")
+			cmd.Flags().Bool("token-stdin", false, "")
+			cmd.SetContext(t.Context())
+
+			err := cmd.ParseFlags(tt.args)
+			if err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			got, err := masterCredentials(cmd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("masterCredentials() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && got != tt.wantCreds {
+				t.Errorf("masterCredentials() = %+v, want %+v", got, tt.wantCreds)
+			}
+		})
+	}
+}
+
+func TestNewTokenClient(t *testing.T) {
+	const accountID = "0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name        string
+		owner       string
+		account     string
+		wantAccount string
+		wantErr     error
+	}{
+		{
+			name: "UserByDefault",
+		},
+		{
+			name:    "UserIgnoresAccount",
+			owner:   "user",
+			account: accountID,
+		},
+		{
+			name:        "Account",
+			owner:       "account",
+			account:     accountID,
+			wantAccount: accountID,
+		},
+		{
+			name:    "AccountWithoutID",
+			owner:   "account",
+			wantErr: cloudflare.ErrAccountIDRequired,
+		},
+		{
+			name:    "AccountName",
+			owner:   "account",
+			account: "Example Account",
+			wantErr: cloudflare.ErrAccountIDRequired,
+		},
+		{
+			name:    "InvalidOwner",
+			owner:   "organization",
+			wantErr: cloudflare.ErrInvalidTokenOwner,
+		},
+	}
+
+	origNewClient := NewClientFunc
+
+	defer func() { NewClientFunc = origNewClient }()
+
+	NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+		return &cloudflare.Client{}, nil
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("owner", tt.owner)
+			viper.Set("account", tt.account)
+
+			client, err := newTokenClient(cloudflare.Credentials{APIToken: "master-token"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newTokenClient() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && client.TokenAccount() != tt.wantAccount {
+				t.Errorf("newTokenClient() account = %q, want %q", client.TokenAccount(), tt.wantAccount)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// RotateTokenFunc rolls a Cloudflare API token's secret, defaulting to cloudflare.RotateToken.
+var RotateTokenFunc = cloudflare.RotateToken
+
+// rotateCmd defines the command to roll the secret of an existing Cloudflare API token.
+var rotateCmd = &cobra.Command{
+	Use:   "rotate [service name]",
+	Short: "Roll the secret of an existing Cloudflare API token",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Convert service name to lowercase for consistency.
+		serviceName := strings.ToLower(args[0])
+
+		// Retrieve the master API token or Global API Key from its configured source.
+		creds, err := masterCredentials(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Retrieve the zone names from configuration.
+		zoneNames := configuredZones()
+
+		// Require the zones the token was generated for, by name or, as generate accepts,
+		// by ID.
+		if len(zoneNames) == 0 && len(viper.GetStringSlice("zone_id")) == 0 {
+			return ErrMissingConfigZone
+		}
+
+		// Initialize Cloudflare client with the credentials, for the configured token owner.
+		client, err := newTokenClient(creds)
+		if err != nil {
+			return err
+		}
+
+		// Create a context for the API calls.
+		ctx := context.Background()
+
+		// Select the service token, named as generate names it.
+		matcher, err := serviceTokenMatcher(ctx, client, serviceName, zoneNames)
+		if err != nil {
+			return err
+		}
+
+		// Roll the existing API token.
+		rotatedAPIToken, err := RotateTokenFunc(ctx, serviceName, matcher, client)
+		if err != nil {
+			return fmt.Errorf("failed to rotate token: %w", err)
+		}
+
+		// Output the new token value.
+		fmt.Fprintln(os.Stdout, rotatedAPIToken)
+
+		return nil
+	},
+}
+
+// init configures the rotate command before execution.
+func init() {
+	// Add the rotate command to the root command.
+	rootCmd.AddCommand(rotateCmd)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config"
+)
+
+func TestRotateCmd(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		apiToken    string
+		zone        string
+		zoneID      string
+		template    string
+		rotateFunc  func(ctx context.Context, serviceName string, match cloudflare.TokenMatcher, api cloudflare.APIInterface) (string, error)
+		wantService string
+		// wantMatch is the name of a token the rotated token must be selected by.
+		wantMatch  string
+		wantErr    bool
+		wantOutput string
+		wantErrMsg string
+	}{
+		{
+			name:        "Success",
+			args:        []string{"rotate", "Traefik"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			wantService: "traefik",
+			wantMatch:   "traefik.example.com",
+			wantOutput:  "rolled-value\n",
+		},
+		{
+			name:        "ZoneID",
+			args:        []string{"rotate", "traefik"},
+			apiToken:    "valid-token",
+			zoneID:      "zone-123",
+			wantService: "traefik",
+			wantMatch:   "traefik.zone-123",
+			wantOutput:  "rolled-value\n",
+		},
+		{
+			name:        "NameTemplate",
+			args:        []string{"rotate", "traefik"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			template:    "prod-{{.Service}}@{{.User}}.{{.Zone}}-{{.Date}}",
+			wantService: "traefik",
+			wantMatch:   "prod-traefik@ci.example.com-2026-06-01",
+			wantOutput:  "rolled-value\n",
+		},
+		{
+			name:        "MixedCaseNameTemplate",
+			args:        []string{"rotate", "Traefik"},
+			apiToken:    "valid-token",
+			zone:        "example.com",
+			template:    "prod-{{.Service}}-{{.Zone}}",
+			wantService: "traefik",
+			wantMatch:   "prod-traefik-example.com",
+			wantOutput:  "rolled-value\n",
+		},
+		{
+			name:       "InvalidNameTemplate",
+			args:       []string{"rotate", "traefik"},
+			apiToken:   "valid-token",
+			zone:       "example.com",
+			template:   "{{.Service",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrInvalidNameTemplate.Error(),
+		},
+		{
+			name:       "MissingArgs",
+			args:       []string{"rotate"},
+			wantErr:    true,
+			wantErrMsg: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:       "MissingAPIToken",
+			args:       []string{"rotate", "traefik"},
+			zone:       "example.com",
+			wantErr:    true,
+			wantErrMsg: cloudflare.ErrMissingCredentials.Error(),
+		},
+		{
+			name:       "MissingZone",
+			args:       []string{"rotate", "traefik"},
+			apiToken:   "valid-token",
+			wantErr:    true,
+			wantErrMsg: ErrMissingConfigZone.Error(),
+		},
+		{
+			name:     "RotateError",
+			args:     []string{"rotate", "traefik"},
+			apiToken: "valid-token",
+			zone:     "example.com",
+			rotateFunc: func(_ context.Context, _ string, _ cloudflare.TokenMatcher, _ cloudflare.APIInterface) (string, error) {
+				return "", errors.New("rotate error")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to rotate token: rotate error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			origInitConfig := config.InitConfigFunc
+
+			defer func() { config.InitConfigFunc = origInitConfig }()
+
+			config.InitConfigFunc = func(v config.Viper) {
+				v.SetDefault("api_token", tt.apiToken)
+				v.SetDefault("zone", tt.zone)
+				v.SetDefault("zone_id", tt.zoneID)
+				v.SetDefault("name_template", tt.template)
+			}
+
+			origNewClient := NewClientFunc
+			origRotateToken := RotateTokenFunc
+
+			defer func() {
+				NewClientFunc = origNewClient
+				RotateTokenFunc = origRotateToken
+			}()
+
+			NewClientFunc = func(_ cloudflare.Credentials) (*cloudflare.Client, error) {
+				return &cloudflare.Client{}, nil
+			}
+
+			var (
+				gotService string
+				gotMatch   cloudflare.TokenMatcher
+			)
+
+			RotateTokenFunc = func(_ context.Context, serviceName string, match cloudflare.TokenMatcher, _ cloudflare.APIInterface) (string, error) {
+				gotService, gotMatch = serviceName, match
+
+				return "rolled-value", nil
+			}
+			if tt.rotateFunc != nil {
+				RotateTokenFunc = tt.rotateFunc
+			}
+
+			rootCmd := &cobra.Command{Use: "goGenerateCFToken"}
+			rootCmd.AddCommand(rotateCmd)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			defer func() { os.Stdout = oldStdout }()
+
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			w.Close()
+
+			var buf bytes.Buffer
+
+			_, _ = io.Copy(&buf, r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rootCmd.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				if output != tt.wantOutput {
+					t.Errorf("rootCmd.Execute() output = %q, want %q", output, tt.wantOutput)
+				}
+
+				if gotService != tt.wantService {
+					t.Errorf("RotateTokenFunc() service = %q, want %q", gotService, tt.wantService)
+				}
+
+				if !gotMatch(shared.Token{Name: tt.wantMatch}) {
+					t.Errorf("RotateTokenFunc() matcher does not select %q", tt.wantMatch)
+				}
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" &&
+				(err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+				t.Errorf("rootCmd.Execute() error = %v, wantErrMsg %q", err, tt.wantErrMsg)
+			}
+		})
+	}
+}
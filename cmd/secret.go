@@ -0,0 +1,165 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+// Constants defining the defaults and limits of generated Kubernetes Secret manifests.
+const (
+	// defaultSecretName is the name of the Secret when none is configured.
+	defaultSecretName = "cloudflare-api-token"
+	// defaultSecretKey is the data key holding the token value, as expected by
+	// cert-manager and external-dns examples.
+	defaultSecretKey = "api-token"
+	// secretLabelPrefix is the prefix of the labels and annotations describing the token.
+	secretLabelPrefix = "gogeneratecftoken/"
+	// maxSecretNameLength is the maximum length of a Secret name (a DNS subdomain).
+	maxSecretNameLength = 253
+	// maxNamespaceLength is the maximum length of a namespace (a DNS label).
+	maxNamespaceLength = 63
+	// maxLabelValueLength is the maximum length of a label value.
+	maxLabelValueLength = 63
+	// secretIndent is the indentation of the manifest, matching kubectl's output.
+	secretIndent = 2
+)
+
+var (
+	// secretNamePattern matches a DNS subdomain, as required for Secret names.
+	secretNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	// namespacePattern matches a DNS label, as required for namespace names.
+	namespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// secretKeyPattern matches a valid Secret data key.
+	secretKeyPattern = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+	// invalidLabelChars matches characters that are not allowed in label values.
+	invalidLabelChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+)
+
+// secretOptions holds the settings of a generated Kubernetes Secret manifest.
+type secretOptions struct {
+	// Name is the name of the Secret.
+	Name string
+	// Namespace is the namespace of the Secret. It is omitted when empty, so that
+	// kubectl applies the manifest to the current namespace.
+	Namespace string
+	// Key is the data key holding the token value.
+	Key string
+	// Zone is the zone, or comma-separated zones, the token was issued for.
+	Zone string
+}
+
+// secretManifest is a v1/Secret manifest, with fields in the order kubectl shows them.
+type secretManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   secretMetadata    `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// secretMetadata is the object metadata of a Secret manifest.
+type secretMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// validate checks that the Secret settings are accepted by the Kubernetes API.
+func (o secretOptions) validate() error {
+	if len(o.Name) > maxSecretNameLength || !secretNamePattern.MatchString(o.Name) {
+		return fmt.Errorf("%w name %q: must be a lowercase DNS subdomain", ErrInvalidSecret, o.Name)
+	}
+
+	if o.Namespace != "" &&
+		(len(o.Namespace) > maxNamespaceLength || !namespacePattern.MatchString(o.Namespace)) {
+		return fmt.Errorf("%w namespace %q: must be a lowercase DNS label", ErrInvalidSecret, o.Namespace)
+	}
+
+	if len(o.Key) > maxSecretNameLength || !secretKeyPattern.MatchString(o.Key) {
+		return fmt.Errorf(
+			"%w key %q: may only contain letters, digits, '-', '_', and '.'",
+			ErrInvalidSecret,
+			o.Key,
+		)
+	}
+
+	return nil
+}
+
+// writeSecretManifest writes a generated token to w as a Kubernetes Secret manifest.
+// The token value is stored base64-encoded under data, so the manifest can be applied
+// with kubectl or passed to kubeseal unchanged.
+func writeSecretManifest(w io.Writer, opts secretOptions, token cloudflare.GeneratedToken) error {
+	manifest := secretManifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: secretMetadata{
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "gogeneratecftoken",
+				secretLabelPrefix + "token-id": labelValue(token.ID),
+				secretLabelPrefix + "zone":     labelValue(opts.Zone),
+			},
+			Annotations: map[string]string{
+				secretLabelPrefix + "token-name": token.Name,
+			},
+		},
+		Type: "Opaque",
+		Data: map[string]string{
+			opts.Key: base64.StdEncoding.EncodeToString([]byte(token.Value)),
+		},
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(secretIndent)
+
+	err := encoder.Encode(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode Kubernetes Secret: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("failed to encode Kubernetes Secret: %w", err)
+	}
+
+	return nil
+}
+
+// labelValue converts a value into a valid label value, replacing disallowed characters
+// (such as the commas between zone names) with underscores and truncating it to the
+// maximum length. Label values must begin and end with an alphanumeric character.
+func labelValue(value string) string {
+	value = invalidLabelChars.ReplaceAllString(value, "_")
+	if len(value) > maxLabelValueLength {
+		value = value[:maxLabelValueLength]
+	}
+
+	return strings.Trim(value, "-._")
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare"
+)
+
+func TestWriteSecretManifest(t *testing.T) {
+	token := cloudflare.GeneratedToken{
+		ID:    "ed17574386854bf78a67040be0a770b0",
+		Name:  "certs.example.com,example.org",
+		Value: "secret-value",
+	}
+
+	tests := []struct {
+		name string
+		opts secretOptions
+		want string
+	}{
+		{
+			name: "Namespaced",
+			opts: secretOptions{
+				Name:      "cloudflare-api-token",
+				Namespace: "cert-manager",
+				Key:       "api-token",
+				Zone:      "example.com,example.org",
+			},
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+  name: cloudflare-api-token
+  namespace: cert-manager
+  labels:
+    app.kubernetes.io/managed-by: gogeneratecftoken
+    gogeneratecftoken/token-id: ed17574386854bf78a67040be0a770b0
+    gogeneratecftoken/zone: example.com_example.org
+  annotations:
+    gogeneratecftoken/token-name: certs.example.com,example.org
+type: Opaque
+data:
+  api-token: c2VjcmV0LXZhbHVl
+`,
+		},
+		{
+			name: "CurrentNamespace",
+			opts: secretOptions{
+				Name: "external-dns",
+				Key:  "cloudflare_api_token",
+				Zone: "example.com",
+			},
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+  name: external-dns
+  labels:
+    app.kubernetes.io/managed-by: gogeneratecftoken
+    gogeneratecftoken/token-id: ed17574386854bf78a67040be0a770b0
+    gogeneratecftoken/zone: example.com
+  annotations:
+    gogeneratecftoken/token-name: certs.example.com,example.org
+type: Opaque
+data:
+  cloudflare_api_token: c2VjcmV0LXZhbHVl
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := writeSecretManifest(&buf, tt.opts, token)
+			if err != nil {
+				t.Fatalf("writeSecretManifest() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeSecretManifest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    secretOptions
+		wantErr error
+	}{
+		{
+			name: "Valid",
+			opts: secretOptions{Name: "cloudflare-api-token", Namespace: "cert-manager", Key: "api-token"},
+		},
+		{
+			name: "ValidWithoutNamespace",
+			opts: secretOptions{Name: "cloudflare.token", Key: "CF_API_TOKEN"},
+		},
+		{
+			name:    "UppercaseName",
+			opts:    secretOptions{Name: "Cloudflare", Key: "api-token"},
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "EmptyName",
+			opts:    secretOptions{Key: "api-token"},
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "NamespaceWithDot",
+			opts:    secretOptions{Name: "cloudflare", Namespace: "cert.manager", Key: "api-token"},
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "KeyWithSlash",
+			opts:    secretOptions{Name: "cloudflare", Key: "api/token"},
+			wantErr: ErrInvalidSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "Zone", value: "example.com", want: "example.com"},
+		{name: "MultipleZones", value: "example.com,example.org", want: "example.com_example.org"},
+		{name: "Empty", value: "", want: ""},
+		{name: "TooLong", value: strings.Repeat("a", 62) + ".b", want: strings.Repeat("a", 62)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelValue(tt.value); got != tt.want {
+				t.Errorf("labelValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package atomicfile
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Write writes data to path with the given permissions. The data is written to a
+// temporary file in the same directory first, so readers never see a partial file.
+// An existing file is only replaced if overwrite is set; otherwise an error wrapping
+// fs.ErrExist is returned.
+func Write(path string, data []byte, perm fs.FileMode, overwrite bool) error {
+	// Write the data to a temporary file next to the destination.
+	tmp, err := createTemp(path)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	tmpPath := tmp.Name()
+
+	defer os.Remove(tmpPath)
+
+	err = tmp.Chmod(perm)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// Replace the destination, or link the file into place so that an existing file is
+	// never overwritten without overwrite.
+	if overwrite {
+		err = os.Rename(tmpPath, path)
+	} else {
+		err = os.Link(tmpPath, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// CheckDir checks that the directory of path exists and that Write can create its
+// temporary file there, by creating and removing one.
+func CheckDir(path string) error {
+	probe, err := createTemp(path)
+	if err != nil {
+		return err
+	}
+
+	probe.Close()
+
+	return os.Remove(probe.Name())
+}
+
+// createTemp creates a hidden temporary file next to path.
+func createTemp(path string) (*os.File, error) {
+	return os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+}
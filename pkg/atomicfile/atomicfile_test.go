@@ -0,0 +1,152 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package atomicfile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		overwrite bool
+		perm      fs.FileMode
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "NewFile",
+			perm: 0o600,
+			want: "new",
+		},
+		{
+			name: "Permissions",
+			perm: 0o640,
+			want: "new",
+		},
+		{
+			name:     "ExistingFile",
+			existing: "old",
+			perm:     0o600,
+			want:     "old",
+			wantErr:  fs.ErrExist,
+		},
+		{
+			name:      "ExistingFileOverwritten",
+			existing:  "old",
+			overwrite: true,
+			perm:      0o600,
+			want:      "new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "config.yaml")
+
+			if tt.existing != "" {
+				err := os.WriteFile(path, []byte(tt.existing), 0o644)
+				if err != nil {
+					t.Fatalf("Failed to create existing file: %v", err)
+				}
+			}
+
+			err := Write(path, []byte("new"), tt.perm, tt.overwrite)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Write() error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("file = %q, want %q", got, tt.want)
+			}
+
+			// Only the written file should remain, without temporary files.
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Errorf("directory has %d entries, want 1", len(entries))
+			}
+
+			if tt.wantErr == nil && runtime.GOOS != "windows" {
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Fatalf("Failed to stat file: %v", err)
+				}
+
+				if mode := info.Mode().Perm(); mode != tt.perm {
+					t.Errorf("file mode = %o, want %o", mode, tt.perm)
+				}
+			}
+		})
+	}
+}
+
+func TestWrite_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "config.yaml")
+
+	err := Write(path, []byte("new"), 0o600, true)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Write() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestCheckDir(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{
+			name: "WritableDirectory",
+			path: filepath.Join(dir, "config.yaml"),
+		},
+		{
+			name:    "MissingDirectory",
+			path:    filepath.Join(dir, "missing", "config.yaml"),
+			wantErr: fs.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckDir(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckDir() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The probe must not leave temporary files behind.
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("directory has %d entries, want 0", len(entries))
+	}
+}
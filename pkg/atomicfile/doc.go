@@ -0,0 +1,8 @@
+// Package atomicfile writes files that hold secrets or configuration without ever
+// leaving them half-written.
+//
+// Data is written to a temporary file in the destination directory, synced, and then
+// moved into place, so that readers see either the old or the new contents and a failed
+// write leaves an existing file intact. CheckDir creates the same kind of temporary file
+// to check, before any work is done, that a later write can succeed.
+package atomicfile
@@ -0,0 +1,239 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/accounts"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+)
+
+// TokenOwner selects who owns the API tokens that are created, listed, rolled, and deleted.
+type TokenOwner string
+
+// Constants defining the supported token owners.
+const (
+	// OwnerUser manages the tokens of the user the master token belongs to.
+	OwnerUser TokenOwner = "user"
+	// OwnerAccount manages the tokens owned by an account, which keep working when the
+	// user who created them leaves the account.
+	OwnerAccount TokenOwner = "account"
+)
+
+// Constants defining the policy resources and permission group scope of accounts.
+const (
+	// AccountResourcePrefix is the policy resource key prefix that identifies a Cloudflare account.
+	AccountResourcePrefix = "com.cloudflare.api.account."
+	// accountScope is the resource scope of permission groups that apply to accounts.
+	accountScope = string(accounts.TokenPermissionGroupListResponseScopeComCloudflareAPIAccount)
+)
+
+// ParseTokenOwner parses a token owner, defaulting to OwnerUser for an empty value.
+func ParseTokenOwner(value string) (TokenOwner, error) {
+	switch owner := TokenOwner(strings.ToLower(strings.TrimSpace(value))); owner {
+	case "", OwnerUser:
+		return OwnerUser, nil
+	case OwnerAccount:
+		return OwnerAccount, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use %s or %s)", ErrInvalidTokenOwner, value, OwnerUser, OwnerAccount)
+	}
+}
+
+// TokenAccountID returns the ID of the account owning the tokens for the given owner,
+// or an empty ID for tokens owned by the user. Account-owned tokens require the account
+// to be given by its ID.
+func TokenAccountID(owner TokenOwner, account string) (string, error) {
+	if owner != OwnerAccount {
+		return "", nil
+	}
+
+	if !idPattern.MatchString(account) {
+		return "", fmt.Errorf("%w: got %q", ErrAccountIDRequired, account)
+	}
+
+	return account, nil
+}
+
+// AccountScopedPermissionGroups returns the permission group IDs that apply to accounts
+// rather than zones according to the catalog, and must therefore be granted on an account.
+// Groups missing from the catalog are assumed to apply to zones.
+func AccountScopedPermissionGroups(ids []string, catalog []PermissionGroup) []string {
+	var scoped []string
+
+	for _, id := range ids {
+		index := slices.IndexFunc(catalog, func(group PermissionGroup) bool { return group.ID == id })
+		if index < 0 {
+			continue
+		}
+
+		scopes := catalog[index].Scopes
+		if slices.Contains(scopes, accountScope) && !slices.Contains(scopes, zoneScope) {
+			scoped = append(scoped, id)
+		}
+	}
+
+	return scoped
+}
+
+// NeedsPermissionScopes reports whether the permission group catalog is needed to tell
+// which of the permission group IDs apply to accounts, as some are not among the
+// well-known groups, all of which apply to zones.
+func NeedsPermissionScopes(ids []string) bool {
+	known := slices.Collect(maps.Values(knownPermissionGroups))
+
+	return slices.ContainsFunc(ids, func(id string) bool {
+		return !slices.Contains(known, id)
+	})
+}
+
+// SetTokenAccount makes the client create, list, roll, and delete the API tokens owned by
+// the account with the given ID, through the account tokens endpoints. An empty ID
+// restores the tokens of the user the client authenticates as.
+func (c *Client) SetTokenAccount(accountID string) {
+	c.accountID = accountID
+}
+
+// TokenAccount returns the ID of the account owning the tokens the client manages, or an
+// empty ID if it manages the tokens of its user.
+func (c *Client) TokenAccount() string {
+	return c.accountID
+}
+
+// verifyAccountAPIToken verifies the API token the client authenticates with as a token
+// owned by the client's account.
+func (c *Client) verifyAccountAPIToken(ctx context.Context) (*user.TokenVerifyResponse, error) {
+	token, err := c.Accounts.Tokens.Verify(ctx, accounts.TokenVerifyParams{
+		AccountID: cloudflare.F(c.accountID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.TokenVerifyResponse{
+		ID:        token.ID,
+		Status:    user.TokenVerifyResponseStatus(token.Status),
+		ExpiresOn: token.ExpiresOn,
+		NotBefore: token.NotBefore,
+	}, nil
+}
+
+// createAccountAPIToken creates an API token owned by the client's account.
+func (c *Client) createAccountAPIToken(
+	ctx context.Context,
+	params user.TokenNewParams,
+) (*user.TokenNewResponse, error) {
+	accountParams := accounts.TokenNewParams{
+		AccountID: cloudflare.F(c.accountID),
+		Name:      params.Name,
+		Policies:  params.Policies,
+		ExpiresOn: params.ExpiresOn,
+		NotBefore: params.NotBefore,
+	}
+
+	// Copy the client IP restrictions, which have the same shape for both owners.
+	if params.Condition.Present {
+		requestIP := params.Condition.Value.RequestIP.Value
+
+		accountParams.Condition = cloudflare.F(accounts.TokenNewParamsCondition{
+			RequestIP: cloudflare.F(accounts.TokenNewParamsConditionRequestIP{
+				In:    requestIP.In,
+				NotIn: requestIP.NotIn,
+			}),
+		})
+	}
+
+	token, err := c.Accounts.Tokens.New(ctx, accountParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.TokenNewResponse{
+		ID:         token.ID,
+		ExpiresOn:  token.ExpiresOn,
+		IssuedOn:   token.IssuedOn,
+		LastUsedOn: token.LastUsedOn,
+		ModifiedOn: token.ModifiedOn,
+		Name:       token.Name,
+		NotBefore:  token.NotBefore,
+		Policies:   token.Policies,
+		Status:     user.TokenNewResponseStatus(token.Status),
+		Value:      token.Value,
+	}, nil
+}
+
+// listAccountAPITokens lists every API token owned by the client's account.
+func (c *Client) listAccountAPITokens(ctx context.Context, params user.TokenListParams) ([]shared.Token, error) {
+	accountParams := accounts.TokenListParams{
+		AccountID:      cloudflare.F(c.accountID),
+		IncludeExpired: params.IncludeExpired,
+		Page:           params.Page,
+		PerPage:        params.PerPage,
+	}
+
+	if params.Direction.Present {
+		accountParams.Direction = cloudflare.F(accounts.TokenListParamsDirection(params.Direction.Value))
+	}
+
+	var tokens []shared.Token
+
+	iter := c.Accounts.Tokens.ListAutoPaging(ctx, accountParams)
+	for iter.Next() {
+		tokens = append(tokens, iter.Current())
+	}
+
+	return tokens, iter.Err()
+}
+
+// listAccountPermissionGroups lists the permission groups that can be granted to API
+// tokens owned by the client's account.
+func (c *Client) listAccountPermissionGroups(
+	ctx context.Context,
+	params user.TokenPermissionGroupListParams,
+) ([]user.TokenPermissionGroupListResponse, error) {
+	var groups []user.TokenPermissionGroupListResponse
+
+	iter := c.Accounts.Tokens.PermissionGroups.ListAutoPaging(ctx, accounts.TokenPermissionGroupListParams{
+		AccountID: cloudflare.F(c.accountID),
+		Name:      params.Name,
+		Scope:     params.Scope,
+	})
+	for iter.Next() {
+		group := iter.Current()
+
+		scopes := make([]user.TokenPermissionGroupListResponseScope, 0, len(group.Scopes))
+		for _, scope := range group.Scopes {
+			scopes = append(scopes, user.TokenPermissionGroupListResponseScope(scope))
+		}
+
+		groups = append(groups, user.TokenPermissionGroupListResponse{
+			ID:       group.ID,
+			Name:     group.Name,
+			Category: user.TokenPermissionGroupListResponseCategory(group.Category),
+			Scopes:   scopes,
+		})
+	}
+
+	return groups, iter.Err()
+}
@@ -0,0 +1,247 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+)
+
+// testAccountID is the ID of the account owning the tokens in the account token tests.
+const testAccountID = "0123456789abcdef0123456789abcdef"
+
+func TestParseTokenOwner(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    TokenOwner
+		wantErr error
+	}{
+		{value: "", want: OwnerUser},
+		{value: "user", want: OwnerUser},
+		{value: " Account ", want: OwnerAccount},
+		{value: "organization", wantErr: ErrInvalidTokenOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTokenOwner(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseTokenOwner(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseTokenOwner(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenAccountID(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   TokenOwner
+		account string
+		want    string
+		wantErr error
+	}{
+		{name: "User", owner: OwnerUser, account: testAccountID},
+		{name: "Account", owner: OwnerAccount, account: testAccountID, want: testAccountID},
+		{name: "AccountName", owner: OwnerAccount, account: "Production", wantErr: ErrAccountIDRequired},
+		{name: "NoAccount", owner: OwnerAccount, wantErr: ErrAccountIDRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TokenAccountID(tt.owner, tt.account)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TokenAccountID() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("TokenAccountID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountScopedPermissionGroups(t *testing.T) {
+	const (
+		accountGroup = "11111111111111111111111111111111"
+		bothGroup    = "22222222222222222222222222222222"
+		unknownGroup = "33333333333333333333333333333333"
+	)
+
+	catalog := []PermissionGroup{
+		{ID: ZoneReadPermission, Scopes: []string{zoneScope}},
+		{ID: accountGroup, Scopes: []string{accountScope}},
+		{ID: bothGroup, Scopes: []string{accountScope, zoneScope}},
+	}
+
+	ids := []string{ZoneReadPermission, accountGroup, bothGroup, unknownGroup}
+
+	got := AccountScopedPermissionGroups(ids, catalog)
+	if !slices.Equal(got, []string{accountGroup}) {
+		t.Errorf("AccountScopedPermissionGroups() = %q, want %q", got, []string{accountGroup})
+	}
+
+	if NeedsPermissionScopes([]string{ZoneReadPermission, DNSWritePermission}) {
+		t.Error("NeedsPermissionScopes() = true for well-known groups, want false")
+	}
+
+	if !NeedsPermissionScopes(ids) {
+		t.Error("NeedsPermissionScopes() = false for unknown groups, want true")
+	}
+}
+
+func TestClient_AccountTokens_SDK(t *testing.T) {
+	const accountPath = "/accounts/" + testAccountID
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, c *Client) error
+		response string
+		// wantRequest is the method and path of the request, e.g. "GET /accounts/.../tokens".
+		wantRequest string
+		// wantBody is a substring the request body must contain.
+		wantBody string
+	}{
+		{
+			name: "CreateAPIToken",
+			call: func(ctx context.Context, c *Client) error {
+				token, err := c.CreateAPIToken(ctx, user.TokenNewParams{
+					Name:      cloudflare.F("service.example.com"),
+					Condition: cloudflare.F(requestIPCondition([]string{"192.0.2.0/24"}, nil)),
+				})
+				if err == nil && (token.ID != "new-token" || token.Value != "secret") {
+					err = errors.New("unexpected token " + token.ID)
+				}
+
+				return err
+			},
+			response:    `{"result":{"id":"new-token","value":"secret"},"success":true}`,
+			wantRequest: "POST " + accountPath + "/tokens",
+			wantBody:    `"request_ip":{"in":["192.0.2.0/24"]}`,
+		},
+		{
+			name: "ListAPITokens",
+			call: func(ctx context.Context, c *Client) error {
+				tokens, err := c.ListAPITokens(ctx, user.TokenListParams{})
+				if err == nil && len(tokens) != 1 {
+					err = errors.New("unexpected tokens")
+				}
+
+				return err
+			},
+			response:    `{"result":[{"id":"token-id-123"}],"result_info":{"page":1,"total_pages":1},"success":true}`,
+			wantRequest: "GET " + accountPath + "/tokens",
+		},
+		{
+			name: "DeleteAPIToken",
+			call: func(ctx context.Context, c *Client) error {
+				return c.DeleteAPIToken(ctx, "token-id-123")
+			},
+			response:    `{"result":{"id":"token-id-123"},"success":true}`,
+			wantRequest: "DELETE " + accountPath + "/tokens/token-id-123",
+		},
+		{
+			name: "RollAPIToken",
+			call: func(ctx context.Context, c *Client) error {
+				value, err := c.RollAPIToken(ctx, "token-id-123")
+				if err == nil && value != "rolled-value" {
+					err = errors.New("unexpected value " + value)
+				}
+
+				return err
+			},
+			response:    `{"result":"rolled-value","success":true}`,
+			wantRequest: "PUT " + accountPath + "/tokens/token-id-123/value",
+		},
+		{
+			name: "ListPermissionGroups",
+			call: func(ctx context.Context, c *Client) error {
+				groups, err := c.ListPermissionGroups(ctx, user.TokenPermissionGroupListParams{})
+				if err == nil && (len(groups) != 1 || string(groups[0].Scopes[0]) != accountScope) {
+					err = errors.New("unexpected permission groups")
+				}
+
+				return err
+			},
+			response:    `{"result":[{"id":"group-id","name":"Workers Scripts Write","scopes":["com.cloudflare.api.account"]}],"success":true}`,
+			wantRequest: "GET " + accountPath + "/tokens/permission_groups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest, gotBody string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+
+					// Paginated endpoints keep fetching until a page comes back
+					// empty, so only the first request gets the response.
+					if gotRequest != "" {
+						w.Write([]byte(`{"result":[],"success":true}`))
+
+						return
+					}
+
+					body, _ := io.ReadAll(r.Body)
+
+					gotRequest = r.Method + " " + r.URL.Path
+					gotBody = string(body)
+
+					w.Write([]byte(tt.response))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+			wrappedClient.SetTokenAccount(testAccountID)
+
+			err := tt.call(t.Context(), wrappedClient)
+			if err != nil {
+				t.Errorf("%s() error = %v", tt.name, err)
+			}
+
+			if gotRequest != tt.wantRequest {
+				t.Errorf("%s() request = %s, want %s", tt.name, gotRequest, tt.wantRequest)
+			}
+
+			if !strings.Contains(gotBody, tt.wantBody) {
+				t.Errorf("%s() body = %s, want it to contain %s", tt.name, gotBody, tt.wantBody)
+			}
+		})
+	}
+}
@@ -20,41 +20,101 @@ package cloudflare
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/accounts"
 	"github.com/cloudflare/cloudflare-go/v7/option"
 	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 )
 
+// globalAPIKeyWarning is printed whenever a client authenticates with the Global API Key.
+const globalAPIKeyWarning = `WARNING: Authenticating with the Global API Key, which grants full access to every
+WARNING: account of its user. Create a master API token with User > API Tokens > Edit
+WARNING: and set api_token instead, then remove api_key and api_email.`
+
 // NewAPIClientFunc creates a new Cloudflare client, defaulting to NewClient.
 var NewAPIClientFunc = NewClient
 
 // Client wraps a Cloudflare API client for interacting with zones and tokens.
 type Client struct {
 	*cloudflare.Client
+
+	// globalAPIKey is set if the client authenticates with the Global API Key.
+	globalAPIKey bool
+	// accountID is the ID of the account owning the managed tokens, or empty for the
+	// tokens of the user.
+	accountID string
 }
 
-// NewClient initializes a new Cloudflare client with the provided API token.
-// It returns an error if the token is missing.
-func NewClient(apiToken string) (*Client, error) {
-	// Initialize request options for the client.
-	var opts []option.RequestOption
+// Credentials holds what the client authenticates with: an API token, or the legacy
+// Global API Key together with the email address of its user.
+type Credentials struct {
+	// APIToken is the master API token.
+	APIToken string
+	// APIKey is the Global API Key, used if no API token is given.
+	APIKey string
+	// APIEmail is the email address of the user owning the Global API Key.
+	APIEmail string
+}
+
+// UsesGlobalAPIKey reports whether the credentials authenticate with the Global API Key,
+// which is the case if no API token is given.
+func (c Credentials) UsesGlobalAPIKey() bool {
+	return c.APIToken == "" && (c.APIKey != "" || c.APIEmail != "")
+}
 
-	// Validate and set the API token.
+// Validate checks that the credentials are complete. It returns ErrMissingCredentials
+// if none are given, and ErrIncompleteGlobalAPIKey if only one of the Global API Key
+// and its email address is given.
+func (c Credentials) Validate() error {
 	switch {
-	case apiToken != "":
-		opts = append(opts, option.WithAPIToken(apiToken))
+	case c.APIToken != "":
+		return nil
+	case c.APIKey != "" && c.APIEmail != "":
+		return nil
+	case c.APIKey != "" || c.APIEmail != "":
+		return ErrIncompleteGlobalAPIKey
 	default:
-		return nil, ErrMissingCredentials
+		return ErrMissingCredentials
+	}
+}
+
+// NewClient initializes a new Cloudflare client with the provided credentials, preferring
+// the API token over the Global API Key. It returns an error if the credentials are
+// missing or incomplete, and warns on stderr when the Global API Key is used.
+func NewClient(creds Credentials) (*Client, error) {
+	err := creds.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize request options for the client.
+	var opts []option.RequestOption
+
+	// Set the API token, or the Global API Key and its email address.
+	if creds.UsesGlobalAPIKey() {
+		fmt.Fprintln(os.Stderr, globalAPIKeyWarning)
+
+		opts = append(opts, option.WithAPIKey(creds.APIKey), option.WithAPIEmail(creds.APIEmail))
+	} else {
+		opts = append(opts, option.WithAPIToken(creds.APIToken))
 	}
 
 	// Create the Cloudflare client with options.
 	client := cloudflare.NewClient(opts...)
 
 	// Return the wrapped client.
-	return &Client{client}, nil
+	return &Client{Client: client, globalAPIKey: creds.UsesGlobalAPIKey()}, nil
+}
+
+// UsesGlobalAPIKey reports whether the client authenticates with the Global API Key
+// rather than an API token.
+func (c *Client) UsesGlobalAPIKey() bool {
+	return c.globalAPIKey
 }
 
 // ListZones retrieves a list of Cloudflare zones matching the given parameters.
@@ -78,7 +138,32 @@ func (c *Client) ListZones(
 	return zones, nil
 }
 
-// CreateAPIToken generates a new Cloudflare API token with the specified parameters.
+// VerifyAPIToken reports the status and expiry of the API token the client authenticates with.
+// It returns an error if the client is not initialized or the API call fails, which is
+// also the case for an unknown or revoked token. A client managing account-owned tokens
+// also accepts a token owned by its account.
+func (c *Client) VerifyAPIToken(ctx context.Context) (*user.TokenVerifyResponse, error) {
+	// Validate client initialization.
+	if c.Client == nil {
+		return nil, ErrClientNotInitialized
+	}
+
+	// Verify the API token as a user token, then as a token owned by the account.
+	token, err := c.User.Tokens.Verify(ctx)
+	if err != nil && c.accountID != "" {
+		token, err = c.verifyAccountAPIToken(ctx)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrVerifyTokenFailed, err)
+	}
+
+	// Return the token status.
+	return token, nil
+}
+
+// CreateAPIToken generates a new Cloudflare API token with the specified parameters,
+// owned by the user or by the account set with SetTokenAccount.
 // It returns an error if the client is not initialized or the API call fails.
 func (c *Client) CreateAPIToken(
 	ctx context.Context,
@@ -90,7 +175,17 @@ func (c *Client) CreateAPIToken(
 	}
 
 	// Create the API token.
-	token, err := c.User.Tokens.New(ctx, params)
+	var (
+		token *user.TokenNewResponse
+		err   error
+	)
+
+	if c.accountID != "" {
+		token, err = c.createAccountAPIToken(ctx, params)
+	} else {
+		token, err = c.User.Tokens.New(ctx, params)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrCreateTokenFailed, err)
 	}
@@ -98,3 +193,148 @@ func (c *Client) CreateAPIToken(
 	// Return the created token response.
 	return token, nil
 }
+
+// ListAPITokens retrieves all API tokens owned by the authenticated user, or by the
+// account set with SetTokenAccount. It follows pagination until every page has been
+// fetched and returns an error if the client is not initialized or the API call fails.
+func (c *Client) ListAPITokens(
+	ctx context.Context,
+	params user.TokenListParams,
+) ([]shared.Token, error) {
+	// Validate client initialization.
+	if c.Client == nil {
+		return nil, ErrClientNotInitialized
+	}
+
+	// List the tokens owned by the account.
+	if c.accountID != "" {
+		tokens, err := c.listAccountAPITokens(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListTokensFailed, err)
+		}
+
+		return tokens, nil
+	}
+
+	// Iterate over all pages of tokens.
+	var tokens []shared.Token
+
+	iter := c.User.Tokens.ListAutoPaging(ctx, params)
+	for iter.Next() {
+		tokens = append(tokens, iter.Current())
+	}
+
+	// Check for errors encountered while paging.
+	err := iter.Err()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListTokensFailed, err)
+	}
+
+	// Return the collected tokens.
+	return tokens, nil
+}
+
+// DeleteAPIToken deletes the Cloudflare API token with the specified ID.
+// It returns an error if the client is not initialized or the API call fails.
+func (c *Client) DeleteAPIToken(ctx context.Context, tokenID string) error {
+	// Validate client initialization.
+	if c.Client == nil {
+		return ErrClientNotInitialized
+	}
+
+	// Delete the API token.
+	var err error
+
+	if c.accountID != "" {
+		_, err = c.Accounts.Tokens.Delete(ctx, tokenID, accounts.TokenDeleteParams{
+			AccountID: cloudflare.F(c.accountID),
+		})
+	} else {
+		_, err = c.User.Tokens.Delete(ctx, tokenID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteTokenFailed, err)
+	}
+
+	return nil
+}
+
+// RollAPIToken rolls the secret of the Cloudflare API token with the specified ID.
+// The token keeps its ID and policies. It returns the new token value, or an error
+// if the client is not initialized or the API call fails.
+func (c *Client) RollAPIToken(ctx context.Context, tokenID string) (string, error) {
+	// Validate client initialization.
+	if c.Client == nil {
+		return "", ErrClientNotInitialized
+	}
+
+	// Roll the API token secret.
+	var (
+		value *shared.TokenValue
+		err   error
+	)
+
+	if c.accountID != "" {
+		value, err = c.Accounts.Tokens.Value.Update(ctx, tokenID, accounts.TokenValueUpdateParams{
+			AccountID: cloudflare.F(c.accountID),
+			Body:      map[string]any{},
+		})
+	} else {
+		value, err = c.User.Tokens.Value.Update(ctx, tokenID, user.TokenValueUpdateParams{
+			Body: map[string]any{},
+		})
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRollTokenFailed, err)
+	}
+
+	// Guard against a null result, which would leave the token without a known secret.
+	if value == nil || *value == "" {
+		return "", fmt.Errorf("%w: %w", ErrRollTokenFailed, ErrEmptyTokenValue)
+	}
+
+	// Return the new token value.
+	return *value, nil
+}
+
+// ListPermissionGroups retrieves the permission groups that can be granted to user API
+// tokens, or to the tokens of the account set with SetTokenAccount.
+// It returns an error if the client is not initialized or the API call fails.
+func (c *Client) ListPermissionGroups(
+	ctx context.Context,
+	params user.TokenPermissionGroupListParams,
+) ([]user.TokenPermissionGroupListResponse, error) {
+	// Validate client initialization.
+	if c.Client == nil {
+		return nil, ErrClientNotInitialized
+	}
+
+	// List the permission groups available to account-owned tokens.
+	if c.accountID != "" {
+		groups, err := c.listAccountPermissionGroups(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListPermissionGroupsFailed, err)
+		}
+
+		return groups, nil
+	}
+
+	// Collect the permission groups across all pages.
+	var groups []user.TokenPermissionGroupListResponse
+
+	iter := c.User.Tokens.PermissionGroups.ListAutoPaging(ctx, params)
+	for iter.Next() {
+		groups = append(groups, iter.Current())
+	}
+
+	// Check for errors encountered while paging.
+	err := iter.Err()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListPermissionGroupsFailed, err)
+	}
+
+	// Return the collected permission groups.
+	return groups, nil
+}
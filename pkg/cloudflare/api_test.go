@@ -21,11 +21,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/option"
 	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 	"github.com/stretchr/testify/mock"
@@ -35,26 +38,50 @@ import (
 
 func TestNewClient(t *testing.T) {
 	tests := []struct {
-		name     string
-		apiToken string
-		wantErr  bool
+		name          string
+		creds         Credentials
+		wantGlobalKey bool
+		wantErr       error
 	}{
 		{
-			name:     "Success",
-			apiToken: "valid-token",
+			name:  "Success",
+			creds: Credentials{APIToken: "valid-token"},
 		},
 		{
-			name:     "MissingToken",
-			apiToken: "",
-			wantErr:  true,
+			name:          "GlobalAPIKey",
+			creds:         Credentials{APIKey: "global-key", APIEmail: "user@example.com"},
+			wantGlobalKey: true,
+		},
+		{
+			name:  "TokenPreferredOverGlobalAPIKey",
+			creds: Credentials{APIToken: "valid-token", APIKey: "global-key", APIEmail: "user@example.com"},
+		},
+		{
+			name:    "MissingToken",
+			creds:   Credentials{},
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name:    "MissingEmail",
+			creds:   Credentials{APIKey: "global-key"},
+			wantErr: ErrIncompleteGlobalAPIKey,
+		},
+		{
+			name:    "MissingGlobalAPIKey",
+			creds:   Credentials{APIEmail: "user@example.com"},
+			wantErr: ErrIncompleteGlobalAPIKey,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewClient(tt.apiToken)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			client, err := NewClient(tt.creds)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewClient() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && client.UsesGlobalAPIKey() != tt.wantGlobalKey {
+				t.Errorf("NewClient().UsesGlobalAPIKey() = %v, want %v", client.UsesGlobalAPIKey(), tt.wantGlobalKey)
 			}
 		})
 	}
@@ -158,6 +185,202 @@ func TestClient_CreateAPIToken(t *testing.T) {
 	}
 }
 
+func TestClient_ListAPITokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    APIInterface
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:   "Success",
+			client: &Client{Client: &cloudflare.Client{}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{{ID: "token-id-123"}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "NilClient",
+			client:  &Client{},
+			wantErr: true,
+		},
+		{
+			name:    "ListError",
+			client:  &Client{Client: &cloudflare.Client{}},
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(nil, errors.New("list error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				mockAPI := mocks.NewMockAPIInterface(t)
+				tt.setupMock(mockAPI)
+				tt.client = mockAPI
+			}
+
+			_, err := tt.client.ListAPITokens(t.Context(), user.TokenListParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListAPITokens() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteAPIToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    APIInterface
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:   "Success",
+			client: &Client{Client: &cloudflare.Client{}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("DeleteAPIToken", mock.Anything, "token-id-123").
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:    "NilClient",
+			client:  &Client{},
+			wantErr: true,
+		},
+		{
+			name:    "DeleteError",
+			client:  &Client{Client: &cloudflare.Client{}},
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("DeleteAPIToken", mock.Anything, "token-id-123").
+					Return(errors.New("delete error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				mockAPI := mocks.NewMockAPIInterface(t)
+				tt.setupMock(mockAPI)
+				tt.client = mockAPI
+			}
+
+			err := tt.client.DeleteAPIToken(t.Context(), "token-id-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_RollAPIToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    APIInterface
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:   "Success",
+			client: &Client{Client: &cloudflare.Client{}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("RollAPIToken", mock.Anything, "token-id-123").
+					Return("new-value", nil).
+					Once()
+			},
+		},
+		{
+			name:    "NilClient",
+			client:  &Client{},
+			wantErr: true,
+		},
+		{
+			name:    "RollError",
+			client:  &Client{Client: &cloudflare.Client{}},
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("RollAPIToken", mock.Anything, "token-id-123").
+					Return("", errors.New("roll error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				mockAPI := mocks.NewMockAPIInterface(t)
+				tt.setupMock(mockAPI)
+				tt.client = mockAPI
+			}
+
+			_, err := tt.client.RollAPIToken(t.Context(), "token-id-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RollAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_ListPermissionGroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    APIInterface
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:   "Success",
+			client: &Client{Client: &cloudflare.Client{}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListPermissionGroups", mock.Anything, mock.AnythingOfType("user.TokenPermissionGroupListParams")).
+					Return([]user.TokenPermissionGroupListResponse{{ID: "group-id", Name: "DNS Write"}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "NilClient",
+			client:  &Client{},
+			wantErr: true,
+		},
+		{
+			name:    "ListError",
+			client:  &Client{Client: &cloudflare.Client{}},
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListPermissionGroups", mock.Anything, mock.AnythingOfType("user.TokenPermissionGroupListParams")).
+					Return(nil, errors.New("list error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				mockAPI := mocks.NewMockAPIInterface(t)
+				tt.setupMock(mockAPI)
+				tt.client = mockAPI
+			}
+
+			_, err := tt.client.ListPermissionGroups(t.Context(), user.TokenPermissionGroupListParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListPermissionGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestClient_ListZones_SDK(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -267,3 +490,371 @@ func TestClient_CreateAPIToken_SDK(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ListAPITokens_SDK(t *testing.T) {
+	tests := []struct {
+		name         string
+		pages        []string
+		statusCode   int
+		wantErr      bool
+		wantTokenIDs []string
+	}{
+		{
+			name: "MultiplePages",
+			pages: []string{
+				`{"result":[{"id":"token-1","name":"a.example.com"}],"result_info":{"page":1,"per_page":1},"success":true}`,
+				`{"result":[{"id":"token-2","name":"b.example.com"}],"result_info":{"page":2,"per_page":1},"success":true}`,
+				`{"result":[],"result_info":{"page":3,"per_page":1},"success":true}`,
+			},
+			statusCode:   http.StatusOK,
+			wantTokenIDs: []string{"token-1", "token-2"},
+		},
+		{
+			name:       "Error",
+			pages:      []string{`{"success":false,"errors":[{"message":"API error"}]}`},
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					page, err := strconv.Atoi(r.URL.Query().Get("page"))
+					if err != nil {
+						page = 1
+					}
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.statusCode)
+					w.Write([]byte(tt.pages[min(page, len(tt.pages))-1]))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+
+			tokens, err := wrappedClient.ListAPITokens(t.Context(), user.TokenListParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListAPITokens() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				gotIDs := make([]string, 0, len(tokens))
+				for _, token := range tokens {
+					gotIDs = append(gotIDs, token.ID)
+				}
+
+				if !slices.Equal(gotIDs, tt.wantTokenIDs) {
+					t.Errorf("ListAPITokens() token IDs = %v, want %v", gotIDs, tt.wantTokenIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_DeleteAPIToken_SDK(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "Success",
+			response:   `{"result":{"id":"token-id-123"},"success":true}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Error",
+			response:   `{"success":false,"errors":[{"message":"API error"}]}`,
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotMethod = r.Method
+					gotPath = r.URL.Path
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.statusCode)
+					w.Write([]byte(tt.response))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+
+			err := wrappedClient.DeleteAPIToken(t.Context(), "token-id-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotMethod != http.MethodDelete || gotPath != "/user/tokens/token-id-123" {
+				t.Errorf("DeleteAPIToken() request = %s %s, want DELETE /user/tokens/token-id-123", gotMethod, gotPath)
+			}
+		})
+	}
+}
+
+func TestClient_RollAPIToken_SDK(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		wantErr    bool
+		wantValue  string
+	}{
+		{
+			name:       "Success",
+			response:   `{"result":"rolled-value","success":true}`,
+			statusCode: http.StatusOK,
+			wantValue:  "rolled-value",
+		},
+		{
+			name:       "NullResult",
+			response:   `{"result":null,"success":true}`,
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "Error",
+			response:   `{"success":false,"errors":[{"message":"API error"}]}`,
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotMethod = r.Method
+					gotPath = r.URL.Path
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.statusCode)
+					w.Write([]byte(tt.response))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+
+			value, err := wrappedClient.RollAPIToken(t.Context(), "token-id-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RollAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotMethod != http.MethodPut || gotPath != "/user/tokens/token-id-123/value" {
+				t.Errorf("RollAPIToken() request = %s %s, want PUT /user/tokens/token-id-123/value", gotMethod, gotPath)
+			}
+
+			if !tt.wantErr && value != tt.wantValue {
+				t.Errorf("RollAPIToken() value = %q, want %q", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestClient_ListPermissionGroups_SDK(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		wantErr    bool
+		wantNames  []string
+	}{
+		{
+			name: "Success",
+			response: `{"result":[` +
+				`{"id":"4755a26eedb94da69e1066d98aa820be","name":"DNS Write","scopes":["com.cloudflare.api.account.zone"]},` +
+				`{"id":"c8fed203ed3043cba015a93ad1616f1f","name":"Zone Read","scopes":["com.cloudflare.api.account.zone"]}` +
+				`],"success":true}`,
+			statusCode: http.StatusOK,
+			wantNames:  []string{"DNS Write", "Zone Read"},
+		},
+		{
+			name:       "Error",
+			response:   `{"success":false,"errors":[{"message":"API error"}]}`,
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotPath = r.URL.Path
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.statusCode)
+					w.Write([]byte(tt.response))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+
+			groups, err := wrappedClient.ListPermissionGroups(t.Context(), user.TokenPermissionGroupListParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListPermissionGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotPath != "/user/tokens/permission_groups" {
+				t.Errorf("ListPermissionGroups() path = %q, want /user/tokens/permission_groups", gotPath)
+			}
+
+			var names []string
+			for _, group := range groups {
+				names = append(names, group.Name)
+			}
+
+			if !tt.wantErr && !slices.Equal(names, tt.wantNames) {
+				t.Errorf("ListPermissionGroups() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestClient_VerifyAPIToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    APIInterface
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:   "Success",
+			client: &Client{Client: &cloudflare.Client{}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).
+					Return(&user.TokenVerifyResponse{ID: "token-id-123", Status: user.TokenVerifyResponseStatusActive}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "NilClient",
+			client:  &Client{},
+			wantErr: true,
+		},
+		{
+			name:    "VerifyError",
+			client:  &Client{Client: &cloudflare.Client{}},
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).
+					Return(nil, errors.New("verify error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				mockAPI := mocks.NewMockAPIInterface(t)
+				tt.setupMock(mockAPI)
+				tt.client = mockAPI
+			}
+
+			_, err := tt.client.VerifyAPIToken(t.Context())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_VerifyAPIToken_SDK(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		wantStatus user.TokenVerifyResponseStatus
+		wantErr    bool
+	}{
+		{
+			name:       "Success",
+			response:   `{"result":{"id":"token-id-123","status":"active","expires_on":"2027-01-01T00:00:00Z"},"success":true}`,
+			statusCode: http.StatusOK,
+			wantStatus: user.TokenVerifyResponseStatusActive,
+		},
+		{
+			name:       "Error",
+			response:   `{"success":false,"errors":[{"code":1000,"message":"Invalid API Token"}]}`,
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotMethod = r.Method
+					gotPath = r.URL.Path
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.statusCode)
+					w.Write([]byte(tt.response))
+				}),
+			)
+			defer server.Close()
+
+			client := cloudflare.NewClient(
+				option.WithHTTPClient(server.Client()),
+				option.WithBaseURL(server.URL),
+				option.WithAPIToken("valid-token"),
+			)
+			wrappedClient := &Client{Client: client}
+
+			token, err := wrappedClient.VerifyAPIToken(t.Context())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && token.Status != tt.wantStatus {
+				t.Errorf("VerifyAPIToken() status = %q, want %q", token.Status, tt.wantStatus)
+			}
+
+			if gotMethod != http.MethodGet || gotPath != "/user/tokens/verify" {
+				t.Errorf("VerifyAPIToken() request = %s %s, want GET /user/tokens/verify", gotMethod, gotPath)
+			}
+		})
+	}
+}
@@ -2,16 +2,28 @@
 // for generating API tokens with DNS edit permissions.
 //
 // The package defines a Client type that wraps the Cloudflare SDK client,
-// implementing methods to list zones and create API tokens. It uses an APIInterface
-// to abstract API calls, enabling dependency injection for testing. The main
-// functionality includes retrieving zone IDs by name and generating tokens for
-// specific services and zones, with permissions for zone read and DNS write access.
+// implementing methods to list zones and permission groups, verify the master token,
+// and create, list, roll, and delete API tokens. It uses an APIInterface to abstract
+// API calls, enabling dependency injection for testing. The main functionality includes
+// retrieving zone IDs by name and generating tokens for specific services and zones,
+// granting the permissions of a preset (zone read and DNS write access by default).
 //
 // Key components:
 // - Client: Wraps the Cloudflare SDK client, providing methods for zone and token operations.
-// - APIInterface: Defines methods for listing zones and creating tokens, used for mocking in tests.
-// - GenerateToken: Creates a token with specified permissions for a given zone and service name.
-// - GetZoneID: Retrieves a zone ID by name, handling cases for zero or multiple matches.
+// - Credentials: An API token, or the legacy Global API Key and email NewClient falls back to.
+// - SetTokenAccount: Switches the token operations of a Client to the tokens of an account.
+// - APIInterface: Defines methods for zone and token API calls, used for mocking in tests.
+// - Preflight: Checks that the master token is active, can read the zones, and can create tokens.
+// - GenerateToken: Creates a token with specified permissions for the given zones and service name.
+// - PlanToken: Builds the parameters GenerateToken would send, optionally without API access.
+// - RenderTokenName/MatchNameTemplate: Render a token name template, or match names against it.
+// - PresetPermissionGroups: Looks up the permission groups of a built-in or custom preset.
+// - LoadPermissionGroups/ResolvePermissionGroups: Cache the catalog and map group names to IDs.
+// - GetZoneID: Retrieves a zone ID by name and optional account, listing candidates when ambiguous.
+// - ListAllZones: Lists every zone visible to the client with its owning account.
+// - ListTokens: Lists existing tokens with the zones targeted by their policies.
+// - FindTokens/RevokeToken: Select existing tokens by name, ID, or service and delete them.
+// - RotateToken: Rolls the secret of an existing service token in place.
 //
 // The package includes error constants for common failure cases, such as missing
 // credentials, uninitialized clients, or API errors, ensuring clear error reporting.
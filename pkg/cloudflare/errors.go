@@ -20,8 +20,11 @@ package cloudflare
 import "errors"
 
 var (
-	// ErrMissingCredentials indicates that the API token is not provided.
-	ErrMissingCredentials = errors.New("api_token must be provided")
+	// ErrMissingCredentials indicates that neither an API token nor the Global API Key is provided.
+	ErrMissingCredentials = errors.New("api_token must be provided (or api_key and api_email)")
+
+	// ErrIncompleteGlobalAPIKey indicates that only one of the Global API Key and its email address is provided.
+	ErrIncompleteGlobalAPIKey = errors.New("api_key and api_email must be provided together")
 
 	// ErrClientNotInitialized indicates that the Cloudflare client is not initialized.
 	ErrClientNotInitialized = errors.New("client not initialized")
@@ -38,6 +41,102 @@ var (
 	// ErrMultipleZonesFound indicates that multiple zones were found for the given name.
 	ErrMultipleZonesFound = errors.New("multiple zones found")
 
+	// ErrInvalidZoneID indicates a zone ID that does not have the format of a Cloudflare identifier.
+	ErrInvalidZoneID = errors.New("invalid zone ID")
+
+	// ErrZoneIDMismatch indicates zone names and IDs that do not refer to the same zones.
+	ErrZoneIDMismatch = errors.New("zone names and IDs do not match")
+
+	// ErrNoZones indicates that a token was requested without any zones.
+	ErrNoZones = errors.New("at least one zone must be provided")
+
+	// ErrOfflineZoneLookup indicates that zone names were given without zone IDs in offline mode.
+	ErrOfflineZoneLookup = errors.New("zone IDs are required to plan a token offline")
+
+	// ErrVerifyTokenFailed indicates a failure to verify a Cloudflare API token.
+	ErrVerifyTokenFailed = errors.New("failed to verify API token")
+
+	// ErrTokenUnverified indicates a master API token that could not be verified, e.g. because it is unknown or revoked.
+	ErrTokenUnverified = errors.New("the master API token could not be verified (check that it is correct and has not been revoked)")
+
+	// ErrTokenInactive indicates a master API token that is disabled or expired.
+	ErrTokenInactive = errors.New("the master API token is not active")
+
+	// ErrZoneNotAccessible indicates a zone that the master API token cannot read.
+	ErrZoneNotAccessible = errors.New("the master API token cannot read the zone (grant it Zone > Zone > Read for the zone)")
+
+	// ErrMissingTokenPermission indicates a master API token that cannot create API tokens.
+	ErrMissingTokenPermission = errors.New("the master API token cannot create API tokens (grant it User > API Tokens > Edit, or Account > Account API Tokens > Edit for account-owned tokens)")
+
 	// ErrCreateTokenFailed indicates a failure to create a Cloudflare API token.
 	ErrCreateTokenFailed = errors.New("failed to create API token")
+
+	// ErrListTokensFailed indicates a failure to list Cloudflare API tokens.
+	ErrListTokensFailed = errors.New("failed to list API tokens")
+
+	// ErrDeleteTokenFailed indicates a failure to delete a Cloudflare API token.
+	ErrDeleteTokenFailed = errors.New("failed to delete API token")
+
+	// ErrRollTokenFailed indicates a failure to roll a Cloudflare API token's secret.
+	ErrRollTokenFailed = errors.New("failed to roll API token")
+
+	// ErrEmptyTokenValue indicates that Cloudflare returned no value for a rolled API token.
+	ErrEmptyTokenValue = errors.New("the API returned no token value")
+
+	// ErrListPermissionGroupsFailed indicates a failure to list Cloudflare permission groups.
+	ErrListPermissionGroupsFailed = errors.New("failed to list permission groups")
+
+	// ErrTokenNotFound indicates that no API tokens matched the given name or ID.
+	ErrTokenNotFound = errors.New("no matching API tokens found")
+
+	// ErrMultipleTokensFound indicates that more than one API token matched the given name.
+	ErrMultipleTokensFound = errors.New("multiple API tokens found")
+
+	// ErrTokenAlreadyExists indicates that an API token with the requested name already exists.
+	ErrTokenAlreadyExists = errors.New("an API token with this name already exists")
+
+	// ErrReplaceTokenFailed indicates that a new token was created, but the token it replaces could not be deleted.
+	ErrReplaceTokenFailed = errors.New("failed to delete the replaced API token")
+
+	// ErrInvalidDuration indicates a token lifetime that is not a valid positive duration.
+	ErrInvalidDuration = errors.New("invalid token lifetime")
+
+	// ErrInvalidTimestamp indicates a token timestamp that is not a valid RFC3339 time.
+	ErrInvalidTimestamp = errors.New("invalid RFC3339 timestamp")
+
+	// ErrConflictingExpiry indicates that both a token lifetime and an expiry time were given.
+	ErrConflictingExpiry = errors.New("expires_in and expires_on cannot both be set")
+
+	// ErrExpiryInPast indicates a token expiry time that is not in the future.
+	ErrExpiryInPast = errors.New("token expiry must be in the future")
+
+	// ErrNotBeforeAfterExpiry indicates a token that would expire before it becomes valid.
+	ErrNotBeforeAfterExpiry = errors.New("token not_before must be earlier than its expiry")
+
+	// ErrInvalidCIDR indicates that an IP restriction is not a valid IPv4 or IPv6 CIDR.
+	ErrInvalidCIDR = errors.New("invalid CIDR")
+
+	// ErrUnknownPreset indicates that no permission preset exists with the given name.
+	ErrUnknownPreset = errors.New("unknown permission preset")
+
+	// ErrEmptyPreset indicates a permission preset that grants no permission groups.
+	ErrEmptyPreset = errors.New("permission preset has no permission groups")
+
+	// ErrUnknownPermissionGroup indicates a permission group that is neither an ID nor a known name.
+	ErrUnknownPermissionGroup = errors.New("unknown permission group")
+
+	// ErrAmbiguousPermissionGroup indicates a permission group name that matches more than one group.
+	ErrAmbiguousPermissionGroup = errors.New("ambiguous permission group")
+
+	// ErrInvalidNameTemplate indicates a token name template that cannot be parsed or rendered.
+	ErrInvalidNameTemplate = errors.New("invalid token name template")
+
+	// ErrInvalidTokenName indicates a token name that Cloudflare would reject.
+	ErrInvalidTokenName = errors.New("invalid token name")
+
+	// ErrInvalidTokenOwner indicates a token owner that is neither the user nor the account.
+	ErrInvalidTokenOwner = errors.New("invalid token owner")
+
+	// ErrAccountIDRequired indicates account-owned tokens or account permissions without an account ID.
+	ErrAccountIDRequired = errors.New("account-owned tokens and account permissions require account to be set to an account ID")
 )
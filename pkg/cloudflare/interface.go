@@ -21,12 +21,14 @@ import (
 	"context"
 
 	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 )
 
 // APIInterface defines methods for interacting with the Cloudflare API.
-// It supports listing zones and creating API tokens.
+// It supports listing zones and permission groups, verifying the master token, and
+// creating, listing, rolling, and deleting API tokens.
 type APIInterface interface {
 	// ListZones retrieves a list of Cloudflare zones matching the given parameters.
 	ListZones(
@@ -34,6 +36,29 @@ type APIInterface interface {
 		params zones.ZoneListParams,
 	) (*pagination.V4PagePaginationArray[zones.Zone], error)
 
+	// UsesGlobalAPIKey reports whether the client authenticates with the Global API Key
+	// rather than an API token.
+	UsesGlobalAPIKey() bool
+
+	// VerifyAPIToken reports the status and expiry of the API token the client authenticates with.
+	VerifyAPIToken(ctx context.Context) (*user.TokenVerifyResponse, error)
+
 	// CreateAPIToken generates a new Cloudflare API token with the specified parameters.
 	CreateAPIToken(ctx context.Context, params user.TokenNewParams) (*user.TokenNewResponse, error)
+
+	// ListAPITokens retrieves all API tokens owned by the authenticated user across all pages.
+	ListAPITokens(ctx context.Context, params user.TokenListParams) ([]shared.Token, error)
+
+	// DeleteAPIToken deletes the Cloudflare API token with the specified ID.
+	DeleteAPIToken(ctx context.Context, tokenID string) error
+
+	// RollAPIToken rolls the secret of the Cloudflare API token with the specified ID,
+	// returning the new token value.
+	RollAPIToken(ctx context.Context, tokenID string) (string, error)
+
+	// ListPermissionGroups retrieves the catalog of permission groups available to user API tokens.
+	ListPermissionGroups(
+		ctx context.Context,
+		params user.TokenPermissionGroupListParams,
+	) ([]user.TokenPermissionGroupListResponse, error)
 }
@@ -0,0 +1,157 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+)
+
+// TokenSummary describes an existing Cloudflare API token.
+type TokenSummary struct {
+	// ID is the token identifier.
+	ID string
+	// Name is the token name.
+	Name string
+	// Status is the token status (active, disabled, or expired).
+	Status string
+	// IssuedOn is the time the token was created.
+	IssuedOn time.Time
+	// ModifiedOn is the time the token was last modified.
+	ModifiedOn time.Time
+	// ExpiresOn is the time the token expires, or the zero time if it never expires.
+	ExpiresOn time.Time
+	// Zones lists the names of the zones targeted by the token's policies.
+	// Zones that cannot be resolved to a name are listed by ID.
+	Zones []string
+}
+
+// ListTokens retrieves every API token owned by the master credential.
+// It resolves the zones targeted by each token's policies to zone names where possible.
+func ListTokens(ctx context.Context, client *Client, api APIInterface) ([]TokenSummary, error) {
+	// Retrieve all tokens.
+	tokens, err := FindTokens(ctx, api, MatchAllTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve zone IDs to names. Names are informational only, so fall back to
+	// zone IDs when the master token cannot list zones.
+	zoneNames, err := client.GetZoneNames(ctx, api)
+	if err != nil {
+		zoneNames = map[string]string{}
+	}
+
+	// Summarize each token.
+	summaries := make([]TokenSummary, 0, len(tokens))
+	for _, token := range tokens {
+		summaries = append(summaries, summarizeToken(token, zoneNames))
+	}
+
+	return summaries, nil
+}
+
+// FindTokens retrieves the API tokens owned by the master credential that satisfy match.
+// It returns an empty slice, not an error, when no tokens match.
+func FindTokens(ctx context.Context, api APIInterface, match TokenMatcher) ([]shared.Token, error) {
+	// Retrieve all tokens, including recently expired ones.
+	tokens, err := api.ListAPITokens(ctx, user.TokenListParams{
+		IncludeExpired: cloudflare.F(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep only the matching tokens.
+	matches := make([]shared.Token, 0)
+
+	for _, token := range tokens {
+		if match(token) {
+			matches = append(matches, token)
+		}
+	}
+
+	return matches, nil
+}
+
+// summarizeToken converts a Cloudflare token into a TokenSummary, resolving zone names.
+func summarizeToken(token shared.Token, zoneNames map[string]string) TokenSummary {
+	zones := make([]string, 0)
+
+	for _, zoneID := range TokenZoneIDs(token) {
+		// Prefer the zone name, falling back to the ID when it is unknown.
+		name, ok := zoneNames[zoneID]
+		if !ok {
+			name = zoneID
+		}
+
+		zones = append(zones, name)
+	}
+
+	return TokenSummary{
+		ID:         token.ID,
+		Name:       token.Name,
+		Status:     string(token.Status),
+		IssuedOn:   token.IssuedOn,
+		ModifiedOn: token.ModifiedOn,
+		ExpiresOn:  token.ExpiresOn,
+		Zones:      zones,
+	}
+}
+
+// TokenZoneIDs returns the sorted, de-duplicated IDs of the zones targeted by a token's policies.
+// A wildcard zone resource is reported as "*".
+func TokenZoneIDs(token shared.Token) []string {
+	var keys []string
+
+	// Collect resource keys from both flat and nested resource maps.
+	for _, policy := range token.Policies {
+		switch resources := policy.Resources.(type) {
+		case shared.TokenPolicyResourcesIAMResourcesTypeObjectString:
+			for key := range resources {
+				keys = append(keys, key)
+			}
+		case shared.TokenPolicyResourcesIAMResourcesTypeObjectNested:
+			for _, nested := range resources {
+				for key := range nested {
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+
+	// Keep only zone resources, stripping the resource prefix.
+	var zoneIDs []string
+
+	for _, key := range keys {
+		zoneID, ok := strings.CutPrefix(key, ZoneResourcePrefix)
+		if ok && !slices.Contains(zoneIDs, zoneID) {
+			zoneIDs = append(zoneIDs, zoneID)
+		}
+	}
+
+	slices.Sort(zoneIDs)
+
+	return zoneIDs
+}
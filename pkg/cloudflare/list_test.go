@@ -0,0 +1,198 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
+)
+
+func TestListTokens(t *testing.T) {
+	zoneToken := shared.Token{
+		ID:     "token-id-123",
+		Name:   "service.example.com",
+		Status: shared.TokenStatusActive,
+		Policies: []shared.TokenPolicy{{
+			Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{
+				ZoneResourcePrefix + "zone-id-123": "*",
+				ZoneResourcePrefix + "zone-id-456": "*",
+			},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		wantZones [][]string
+		wantErr   bool
+		// wantErrMsg is the exact error message expected, if any.
+		wantErrMsg string
+		setupMock  func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:      "Success",
+			wantZones: [][]string{{"example.com", "zone-id-456"}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{zoneToken}, nil).
+					Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:      "ZoneListingFailsFallsBackToIDs",
+			wantZones: [][]string{{"zone-id-123", "zone-id-456"}},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{zoneToken}, nil).
+					Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(nil, errors.New("forbidden")).
+					Once()
+			},
+		},
+		{
+			name:      "NoTokens",
+			wantZones: [][]string{},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{}, nil).
+					Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{}, nil).
+					Once()
+			},
+		},
+		{
+			name:       "ListError",
+			wantErr:    true,
+			wantErrMsg: "failed to list API tokens: list error",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(nil, fmt.Errorf("%w: list error", ErrListTokensFailed)).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			client := &Client{Client: &cloudflare.Client{}}
+
+			got, err := ListTokens(t.Context(), client, mockAPI)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListTokens() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				if tt.wantErrMsg != "" && err.Error() != tt.wantErrMsg {
+					t.Errorf("ListTokens() error = %q, want %q", err, tt.wantErrMsg)
+				}
+
+				return
+			}
+
+			if len(got) != len(tt.wantZones) {
+				t.Fatalf("ListTokens() returned %d tokens, want %d", len(got), len(tt.wantZones))
+			}
+
+			for i, summary := range got {
+				if !slices.Equal(summary.Zones, tt.wantZones[i]) {
+					t.Errorf("ListTokens()[%d].Zones = %v, want %v", i, summary.Zones, tt.wantZones[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTokenZoneIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		token shared.Token
+		want  []string
+	}{
+		{
+			name: "FlatResources",
+			token: shared.Token{Policies: []shared.TokenPolicy{{
+				Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{
+					ZoneResourcePrefix + "zone-b": "*",
+					ZoneResourcePrefix + "zone-a": "*",
+				},
+			}}},
+			want: []string{"zone-a", "zone-b"},
+		},
+		{
+			name: "NestedResources",
+			token: shared.Token{Policies: []shared.TokenPolicy{{
+				Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectNested{
+					"com.cloudflare.api.account.account-id": {
+						ZoneResourcePrefix + "zone-a": "*",
+					},
+				},
+			}}},
+			want: []string{"zone-a"},
+		},
+		{
+			name: "DuplicateAcrossPolicies",
+			token: shared.Token{Policies: []shared.TokenPolicy{
+				{Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{ZoneResourcePrefix + "zone-a": "*"}},
+				{Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{ZoneResourcePrefix + "zone-a": "*"}},
+			}},
+			want: []string{"zone-a"},
+		},
+		{
+			name: "Wildcard",
+			token: shared.Token{Policies: []shared.TokenPolicy{{
+				Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{ZoneResourcePrefix + "*": "*"},
+			}}},
+			want: []string{"*"},
+		},
+		{
+			name: "NonZoneResources",
+			token: shared.Token{Policies: []shared.TokenPolicy{{
+				Resources: shared.TokenPolicyResourcesIAMResourcesTypeObjectString{"com.cloudflare.api.user.user-id": "*"},
+			}}},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TokenZoneIDs(tt.token)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("TokenZoneIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
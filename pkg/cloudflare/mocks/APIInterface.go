@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 	mock "github.com/stretchr/testify/mock"
@@ -108,6 +109,199 @@ func (_c *MockAPIInterface_CreateAPIToken_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// DeleteAPIToken provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) DeleteAPIToken(ctx context.Context, tokenID string) error {
+	ret := _mock.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAPIInterface_DeleteAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIToken'
+type MockAPIInterface_DeleteAPIToken_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID string
+func (_e *MockAPIInterface_Expecter) DeleteAPIToken(ctx interface{}, tokenID interface{}) *MockAPIInterface_DeleteAPIToken_Call {
+	return &MockAPIInterface_DeleteAPIToken_Call{Call: _e.mock.On("DeleteAPIToken", ctx, tokenID)}
+}
+
+func (_c *MockAPIInterface_DeleteAPIToken_Call) Run(run func(ctx context.Context, tokenID string)) *MockAPIInterface_DeleteAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_DeleteAPIToken_Call) Return(err error) *MockAPIInterface_DeleteAPIToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAPIInterface_DeleteAPIToken_Call) RunAndReturn(run func(ctx context.Context, tokenID string) error) *MockAPIInterface_DeleteAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPITokens provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) ListAPITokens(ctx context.Context, params user.TokenListParams) ([]shared.Token, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPITokens")
+	}
+
+	var r0 []shared.Token
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user.TokenListParams) ([]shared.Token, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user.TokenListParams) []shared.Token); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shared.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user.TokenListParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIInterface_ListAPITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPITokens'
+type MockAPIInterface_ListAPITokens_Call struct {
+	*mock.Call
+}
+
+// ListAPITokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params user.TokenListParams
+func (_e *MockAPIInterface_Expecter) ListAPITokens(ctx interface{}, params interface{}) *MockAPIInterface_ListAPITokens_Call {
+	return &MockAPIInterface_ListAPITokens_Call{Call: _e.mock.On("ListAPITokens", ctx, params)}
+}
+
+func (_c *MockAPIInterface_ListAPITokens_Call) Run(run func(ctx context.Context, params user.TokenListParams)) *MockAPIInterface_ListAPITokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user.TokenListParams
+		if args[1] != nil {
+			arg1 = args[1].(user.TokenListParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_ListAPITokens_Call) Return(tokens []shared.Token, err error) *MockAPIInterface_ListAPITokens_Call {
+	_c.Call.Return(tokens, err)
+	return _c
+}
+
+func (_c *MockAPIInterface_ListAPITokens_Call) RunAndReturn(run func(ctx context.Context, params user.TokenListParams) ([]shared.Token, error)) *MockAPIInterface_ListAPITokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPermissionGroups provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) ListPermissionGroups(ctx context.Context, params user.TokenPermissionGroupListParams) ([]user.TokenPermissionGroupListResponse, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPermissionGroups")
+	}
+
+	var r0 []user.TokenPermissionGroupListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user.TokenPermissionGroupListParams) ([]user.TokenPermissionGroupListResponse, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user.TokenPermissionGroupListParams) []user.TokenPermissionGroupListResponse); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.TokenPermissionGroupListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user.TokenPermissionGroupListParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIInterface_ListPermissionGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPermissionGroups'
+type MockAPIInterface_ListPermissionGroups_Call struct {
+	*mock.Call
+}
+
+// ListPermissionGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params user.TokenPermissionGroupListParams
+func (_e *MockAPIInterface_Expecter) ListPermissionGroups(ctx interface{}, params interface{}) *MockAPIInterface_ListPermissionGroups_Call {
+	return &MockAPIInterface_ListPermissionGroups_Call{Call: _e.mock.On("ListPermissionGroups", ctx, params)}
+}
+
+func (_c *MockAPIInterface_ListPermissionGroups_Call) Run(run func(ctx context.Context, params user.TokenPermissionGroupListParams)) *MockAPIInterface_ListPermissionGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user.TokenPermissionGroupListParams
+		if args[1] != nil {
+			arg1 = args[1].(user.TokenPermissionGroupListParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_ListPermissionGroups_Call) Return(tokenPermissionGroupListResponses []user.TokenPermissionGroupListResponse, err error) *MockAPIInterface_ListPermissionGroups_Call {
+	_c.Call.Return(tokenPermissionGroupListResponses, err)
+	return _c
+}
+
+func (_c *MockAPIInterface_ListPermissionGroups_Call) RunAndReturn(run func(ctx context.Context, params user.TokenPermissionGroupListParams) ([]user.TokenPermissionGroupListResponse, error)) *MockAPIInterface_ListPermissionGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListZones provides a mock function for the type MockAPIInterface
 func (_mock *MockAPIInterface) ListZones(ctx context.Context, params zones.ZoneListParams) (*pagination.V4PagePaginationArray[zones.Zone], error) {
 	ret := _mock.Called(ctx, params)
@@ -175,3 +369,175 @@ func (_c *MockAPIInterface_ListZones_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// RollAPIToken provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) RollAPIToken(ctx context.Context, tokenID string) (string, error) {
+	ret := _mock.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollAPIToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, tokenID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, tokenID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIInterface_RollAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollAPIToken'
+type MockAPIInterface_RollAPIToken_Call struct {
+	*mock.Call
+}
+
+// RollAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID string
+func (_e *MockAPIInterface_Expecter) RollAPIToken(ctx interface{}, tokenID interface{}) *MockAPIInterface_RollAPIToken_Call {
+	return &MockAPIInterface_RollAPIToken_Call{Call: _e.mock.On("RollAPIToken", ctx, tokenID)}
+}
+
+func (_c *MockAPIInterface_RollAPIToken_Call) Run(run func(ctx context.Context, tokenID string)) *MockAPIInterface_RollAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_RollAPIToken_Call) Return(s string, err error) *MockAPIInterface_RollAPIToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAPIInterface_RollAPIToken_Call) RunAndReturn(run func(ctx context.Context, tokenID string) (string, error)) *MockAPIInterface_RollAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsesGlobalAPIKey provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) UsesGlobalAPIKey() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsesGlobalAPIKey")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockAPIInterface_UsesGlobalAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsesGlobalAPIKey'
+type MockAPIInterface_UsesGlobalAPIKey_Call struct {
+	*mock.Call
+}
+
+// UsesGlobalAPIKey is a helper method to define mock.On call
+func (_e *MockAPIInterface_Expecter) UsesGlobalAPIKey() *MockAPIInterface_UsesGlobalAPIKey_Call {
+	return &MockAPIInterface_UsesGlobalAPIKey_Call{Call: _e.mock.On("UsesGlobalAPIKey")}
+}
+
+func (_c *MockAPIInterface_UsesGlobalAPIKey_Call) Run(run func()) *MockAPIInterface_UsesGlobalAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_UsesGlobalAPIKey_Call) Return(b bool) *MockAPIInterface_UsesGlobalAPIKey_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockAPIInterface_UsesGlobalAPIKey_Call) RunAndReturn(run func() bool) *MockAPIInterface_UsesGlobalAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyAPIToken provides a mock function for the type MockAPIInterface
+func (_mock *MockAPIInterface) VerifyAPIToken(ctx context.Context) (*user.TokenVerifyResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAPIToken")
+	}
+
+	var r0 *user.TokenVerifyResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*user.TokenVerifyResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *user.TokenVerifyResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.TokenVerifyResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIInterface_VerifyAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyAPIToken'
+type MockAPIInterface_VerifyAPIToken_Call struct {
+	*mock.Call
+}
+
+// VerifyAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAPIInterface_Expecter) VerifyAPIToken(ctx interface{}) *MockAPIInterface_VerifyAPIToken_Call {
+	return &MockAPIInterface_VerifyAPIToken_Call{Call: _e.mock.On("VerifyAPIToken", ctx)}
+}
+
+func (_c *MockAPIInterface_VerifyAPIToken_Call) Run(run func(ctx context.Context)) *MockAPIInterface_VerifyAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIInterface_VerifyAPIToken_Call) Return(tokenVerifyResponse *user.TokenVerifyResponse, err error) *MockAPIInterface_VerifyAPIToken_Call {
+	_c.Call.Return(tokenVerifyResponse, err)
+	return _c
+}
+
+func (_c *MockAPIInterface_VerifyAPIToken_Call) RunAndReturn(run func(ctx context.Context) (*user.TokenVerifyResponse, error)) *MockAPIInterface_VerifyAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
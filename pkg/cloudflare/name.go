@@ -0,0 +1,200 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+)
+
+// MaxTokenNameLength is the maximum number of characters Cloudflare accepts in a token name.
+const MaxTokenNameLength = 120
+
+// nameDateLayout is the layout of the date available to token name templates.
+const nameDateLayout = "2006-01-02"
+
+var (
+	// currentUser returns the name of the user running the tool.
+	// It can be overridden for testing.
+	currentUser = currentUsername
+	// hostname returns the name of the host running the tool, defaulting to os.Hostname.
+	// It can be overridden for testing.
+	hostname = os.Hostname
+)
+
+// TokenNameData holds the values available to token name templates.
+type TokenNameData struct {
+	// Service is the service name, as given on the command line.
+	Service string
+	// Zone is the zone name, comma-separated zone names, or zone IDs of the token.
+	Zone string
+	// Account is the configured account name or ID, if any.
+	Account string
+	// Date is the current UTC date, e.g. "2026-06-01".
+	Date string
+	// User is the name of the user running the tool.
+	User string
+	// Hostname is the name of the host running the tool.
+	Hostname string
+}
+
+// NewTokenNameData collects the values available to token name templates for a service
+// and zone, filling in the current date, user, and hostname.
+func NewTokenNameData(serviceName, zoneName, account string) TokenNameData {
+	host, err := hostname()
+	if err != nil {
+		host = ""
+	}
+
+	return TokenNameData{
+		Service:  serviceName,
+		Zone:     zoneName,
+		Account:  account,
+		Date:     timeNow().UTC().Format(nameDateLayout),
+		User:     currentUser(),
+		Hostname: host,
+	}
+}
+
+// RenderTokenName renders a Go text/template token name template, such as
+// "{{.Service}}@{{.Zone}}", and validates the resulting name.
+func RenderTokenName(text string, data TokenNameData) (string, error) {
+	rendered, err := executeNameTemplate(text, data)
+	if err != nil {
+		return "", err
+	}
+
+	err = ValidateTokenName(rendered)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+// MatchNameTemplate returns a TokenMatcher that selects the tokens whose names were
+// rendered from the name template text with the given data. The date, user, and hostname
+// differ between runs, so they match any date, user, or hostname, as does the zone if
+// data.Zone is empty.
+func MatchNameTemplate(text string, data TokenNameData) (TokenMatcher, error) {
+	// Render the template with markers in place of the values that may differ, and
+	// remember the pattern each marker stands for.
+	patterns := map[string]string{}
+
+	placeholder := func(field, pattern string) string {
+		marker := "\x00" + field + "\x00"
+		patterns[marker] = pattern
+
+		return marker
+	}
+
+	data.Date = placeholder("Date", `[0-9]{4}-[0-9]{2}-[0-9]{2}`)
+	data.User = placeholder("User", `.*`)
+	data.Hostname = placeholder("Hostname", `.*`)
+
+	if data.Zone == "" {
+		data.Zone = placeholder("Zone", `.+`)
+	}
+
+	rendered, err := executeNameTemplate(text, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match the rendered text literally, except for the markers.
+	pattern := regexp.QuoteMeta(rendered)
+	for marker, fieldPattern := range patterns {
+		pattern = strings.ReplaceAll(pattern, marker, "(?:"+fieldPattern+")")
+	}
+
+	nameRegexp, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNameTemplate, err)
+	}
+
+	return func(token shared.Token) bool {
+		return nameRegexp.MatchString(token.Name)
+	}, nil
+}
+
+// executeNameTemplate parses and executes a token name template, trimming surrounding
+// whitespace from the result.
+func executeNameTemplate(text string, data TokenNameData) (string, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidNameTemplate, err)
+	}
+
+	var name strings.Builder
+
+	err = tmpl.Execute(&name, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidNameTemplate, err)
+	}
+
+	return strings.TrimSpace(name.String()), nil
+}
+
+// ValidateTokenName checks that a token name is accepted by Cloudflare: it must not be
+// empty, must not exceed MaxTokenNameLength characters, and must only contain printable
+// characters.
+func ValidateTokenName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidTokenName)
+	}
+
+	if length := utf8.RuneCountInString(name); length > MaxTokenNameLength {
+		return fmt.Errorf(
+			"%w: %q is %d characters long, the maximum is %d",
+			ErrInvalidTokenName,
+			name,
+			length,
+			MaxTokenNameLength,
+		)
+	}
+
+	if !utf8.ValidString(name) || strings.IndexFunc(name, isUnprintable) >= 0 {
+		return fmt.Errorf("%w: %q contains unprintable characters", ErrInvalidTokenName, name)
+	}
+
+	return nil
+}
+
+// isUnprintable reports whether r is not allowed in a token name.
+func isUnprintable(r rune) bool {
+	return !unicode.IsPrint(r)
+}
+
+// currentUsername returns the login name of the current user, falling back to the
+// USER environment variable when the user database is unavailable.
+func currentUsername() string {
+	current, err := user.Current()
+	if err == nil && current.Username != "" {
+		return current.Username
+	}
+
+	return os.Getenv("USER")
+}
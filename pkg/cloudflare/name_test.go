@@ -0,0 +1,210 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+)
+
+func TestNewTokenNameData(t *testing.T) {
+	origTimeNow, origCurrentUser, origHostname := timeNow, currentUser, hostname
+
+	defer func() { timeNow, currentUser, hostname = origTimeNow, origCurrentUser, origHostname }()
+
+	timeNow = func() time.Time { return fixedNow }
+	currentUser = func() string { return "ops" }
+	hostname = func() (string, error) { return "build01", nil }
+
+	got := NewTokenNameData("Traefik", "example.com", "Production")
+	want := TokenNameData{
+		Service:  "Traefik",
+		Zone:     "example.com",
+		Account:  "Production",
+		Date:     "2026-06-01",
+		User:     "ops",
+		Hostname: "build01",
+	}
+
+	if got != want {
+		t.Errorf("NewTokenNameData() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRenderTokenName(t *testing.T) {
+	data := TokenNameData{
+		Service:  "traefik",
+		Zone:     "example.com",
+		Account:  "Production",
+		Date:     "2026-06-01",
+		User:     "ops",
+		Hostname: "build01",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "EnvironmentAndOwner",
+			template: "prod-{{.Service}}@{{.User}}.{{.Zone}}",
+			want:     "prod-traefik@ops.example.com",
+		},
+		{
+			name:     "AllVariables",
+			template: "{{.Service}} {{.Zone}} {{.Account}} {{.Date}} {{.User}} {{.Hostname}}",
+			want:     "traefik example.com Production 2026-06-01 ops build01",
+		},
+		{
+			name:     "Functions",
+			template: `{{.Service | printf "%s-%s" .Account}}`,
+			want:     "Production-traefik",
+		},
+		{
+			name:     "TrimsWhitespace",
+			template: " {{.Service}}.{{.Zone}}\n",
+			want:     "traefik.example.com",
+		},
+		{
+			name:     "ParseError",
+			template: "{{.Service",
+			wantErr:  ErrInvalidNameTemplate,
+		},
+		{
+			name:     "UnknownVariable",
+			template: "{{.Team}}",
+			wantErr:  ErrInvalidNameTemplate,
+		},
+		{
+			name:     "Empty",
+			template: "{{.Account | printf \"%.0s\"}}",
+			wantErr:  ErrInvalidTokenName,
+		},
+		{
+			name:     "TooLong",
+			template: strings.Repeat("{{.Service}}", 20),
+			wantErr:  ErrInvalidTokenName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTokenName(tt.template, data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenderTokenName() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("RenderTokenName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchNameTemplate(t *testing.T) {
+	data := TokenNameData{Service: "traefik", Zone: "example.com", Account: "Production"}
+
+	tests := []struct {
+		name      string
+		template  string
+		data      TokenNameData
+		matches   []string
+		unmatched []string
+		wantErr   error
+	}{
+		{
+			name:      "Conventional",
+			template:  "{{.Service}}.{{.Zone}}",
+			data:      data,
+			matches:   []string{"traefik.example.com"},
+			unmatched: []string{"traefik.example.org", "traefik-v2.example.com", "traefikXexample.com"},
+		},
+		{
+			name:      "VaryingValues",
+			template:  "{{.Service}}@{{.User}}.{{.Hostname}}.{{.Zone}}-{{.Date}}",
+			data:      data,
+			matches:   []string{"traefik@ops.build01.example.com-2026-06-01", "traefik@ci..example.com-2027-01-31"},
+			unmatched: []string{"traefik@ops.build01.example.com-today", "traefik@ops.build01.example.org-2026-06-01"},
+		},
+		{
+			name:      "AnyZone",
+			template:  "{{.Account}}-{{.Service}}.{{.Zone}}",
+			data:      TokenNameData{Service: "traefik", Account: "Production"},
+			matches:   []string{"Production-traefik.example.com", "Production-traefik.example.com,example.org"},
+			unmatched: []string{"Production-traefik.", "Staging-traefik.example.com"},
+		},
+		{
+			name:     "InvalidTemplate",
+			template: "{{.Service",
+			wantErr:  ErrInvalidNameTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := MatchNameTemplate(tt.template, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MatchNameTemplate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			for _, name := range tt.matches {
+				if !match(shared.Token{Name: name}) {
+					t.Errorf("MatchNameTemplate() does not match %q", name)
+				}
+			}
+
+			for _, name := range tt.unmatched {
+				if match(shared.Token{Name: name}) {
+					t.Errorf("MatchNameTemplate() matches %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTokenName(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenName string
+		wantErr   error
+	}{
+		{name: "Conventional", tokenName: "service.example.com"},
+		{name: "Unicode", tokenName: "dienst.bücher.example"},
+		{name: "MaximumLength", tokenName: strings.Repeat("a", MaxTokenNameLength)},
+		{name: "Empty", tokenName: "", wantErr: ErrInvalidTokenName},
+		{name: "Blank", tokenName: "   ", wantErr: ErrInvalidTokenName},
+		{name: "TooLong", tokenName: strings.Repeat("a", MaxTokenNameLength+1), wantErr: ErrInvalidTokenName},
+		{name: "ControlCharacter", tokenName: "service\texample", wantErr: ErrInvalidTokenName},
+		{name: "InvalidUTF8", tokenName: "service\xffexample", wantErr: ErrInvalidTokenName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTokenName(tt.tokenName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateTokenName(%q) error = %v, want %v", tt.tokenName, err, tt.wantErr)
+			}
+		})
+	}
+}
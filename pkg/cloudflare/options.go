@@ -0,0 +1,218 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hoursPerDay is used to convert day-based lifetimes (e.g. "90d") into durations.
+const hoursPerDay = 24
+
+// timeNow returns the current time, defaulting to time.Now.
+// It can be overridden for testing.
+var timeNow = time.Now
+
+// DuplicatePolicy selects how GenerateToken handles existing tokens with the same name.
+type DuplicatePolicy int
+
+// Constants defining the supported duplicate token policies.
+const (
+	// DuplicateFail refuses to create a token when a token with the same name exists.
+	DuplicateFail DuplicatePolicy = iota
+	// DuplicateReplace creates the new token, then deletes the existing tokens with the same name.
+	DuplicateReplace
+	// DuplicateAllow creates the new token without looking for existing tokens.
+	DuplicateAllow
+)
+
+// TokenOptions holds optional settings applied to generated tokens.
+type TokenOptions struct {
+	// Name is the token name, e.g. rendered from a name template.
+	// An empty name uses the conventional TokenName of the service and zones.
+	Name string
+	// ExpiresOn is the time at which the token expires.
+	// The zero value creates a token that never expires.
+	ExpiresOn time.Time
+	// NotBefore is the time before which the token is not valid.
+	// The zero value creates a token that is valid immediately.
+	NotBefore time.Time
+	// AllowIPs lists the client CIDRs the token may be used from.
+	// An empty list allows every address.
+	AllowIPs []string
+	// DenyIPs lists the client CIDRs the token may not be used from.
+	DenyIPs []string
+	// ZoneIDs lists the IDs of the zones to grant permissions on. Without zone names,
+	// they are used without looking up the zones, so the master token does not need
+	// Zone:Read; with zone names, they must match the names position by position.
+	ZoneIDs []string
+	// Account is the name or ID of the account whose zones are granted, used to tell
+	// apart zones with the same name in different accounts.
+	Account string
+	// PermissionGroups lists the IDs of the permission groups granted on the zone.
+	// An empty list grants the permissions of the DefaultPreset.
+	PermissionGroups []string
+	// AccountID is the ID of the account owning the token, for tokens created through the
+	// account tokens endpoints. It is empty for tokens owned by the user.
+	AccountID string
+	// AccountPermissionGroups lists the PermissionGroups that apply to accounts rather
+	// than zones. They are granted on the account AccountID in a policy of their own.
+	AccountPermissionGroups []string
+	// Offline uses ZoneIDs without looking up or verifying zone names, so that a token
+	// can be planned without API access. Zone IDs are then required.
+	Offline bool
+	// Duplicates selects how existing tokens with the same name are handled.
+	// The zero value refuses to create a duplicate.
+	Duplicates DuplicatePolicy
+}
+
+// Validate checks that the token options are consistent and refer to the future.
+func (o TokenOptions) Validate() error {
+	now := timeNow()
+
+	// Reject token names that Cloudflare would not accept.
+	if o.Name != "" {
+		err := ValidateTokenName(o.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Reject expiry times that have already passed.
+	if !o.ExpiresOn.IsZero() && !o.ExpiresOn.After(now) {
+		return fmt.Errorf("%w: %s", ErrExpiryInPast, o.ExpiresOn.Format(time.RFC3339))
+	}
+
+	// Reject tokens that would expire before they become valid.
+	if !o.ExpiresOn.IsZero() && !o.NotBefore.IsZero() && !o.NotBefore.Before(o.ExpiresOn) {
+		return fmt.Errorf(
+			"%w: not before %s, expires on %s",
+			ErrNotBeforeAfterExpiry,
+			o.NotBefore.Format(time.RFC3339),
+			o.ExpiresOn.Format(time.RFC3339),
+		)
+	}
+
+	// Reject zone IDs that do not have the format of a Cloudflare identifier.
+	for _, zoneID := range o.ZoneIDs {
+		if !idPattern.MatchString(zoneID) {
+			return fmt.Errorf("%w: %q", ErrInvalidZoneID, zoneID)
+		}
+	}
+
+	// Reject account permissions without an account to grant them on.
+	if o.AccountID != "" && !idPattern.MatchString(o.AccountID) {
+		return fmt.Errorf("%w: got %q", ErrAccountIDRequired, o.AccountID)
+	}
+
+	if len(o.AccountPermissionGroups) > 0 && o.AccountID == "" {
+		return ErrAccountIDRequired
+	}
+
+	// Reject IP restrictions that are not valid CIDRs.
+	for _, cidrs := range [][]string{o.AllowIPs, o.DenyIPs} {
+		for _, cidr := range cidrs {
+			err := ValidateCIDR(cidr)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateCIDR checks that the value is an IPv4 or IPv6 CIDR, e.g. "192.0.2.0/24".
+func ValidateCIDR(value string) error {
+	_, err := netip.ParsePrefix(value)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidCIDR, value)
+	}
+
+	return nil
+}
+
+// ResolveExpiry computes a token expiry time from either a lifetime relative to now
+// (e.g. "720h" or "90d") or an absolute RFC3339 timestamp.
+// It returns the zero time when neither is set.
+func ResolveExpiry(expiresIn, expiresOn string) (time.Time, error) {
+	switch {
+	case expiresIn != "" && expiresOn != "":
+		// Only one way of specifying the expiry is allowed.
+		return time.Time{}, ErrConflictingExpiry
+	case expiresIn != "":
+		// Compute the expiry from the lifetime.
+		lifetime, err := ParseLifetime(expiresIn)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return timeNow().Add(lifetime).Truncate(time.Second), nil
+	default:
+		// Parse the absolute expiry, if any.
+		return ParseTimestamp(expiresOn)
+	}
+}
+
+// ParseLifetime parses a positive token lifetime. In addition to Go duration
+// strings (e.g. "720h"), it accepts a whole number of days (e.g. "90d").
+func ParseLifetime(value string) (time.Duration, error) {
+	var (
+		lifetime time.Duration
+		err      error
+	)
+
+	// Parse day-based lifetimes separately, as time.ParseDuration does not support them.
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var count int
+
+		count, err = strconv.Atoi(days)
+		lifetime = time.Duration(count) * hoursPerDay * time.Hour
+	} else {
+		lifetime, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+
+	// Require a lifetime that ends in the future.
+	if lifetime <= 0 {
+		return 0, fmt.Errorf("%w: %q must be positive", ErrInvalidDuration, value)
+	}
+
+	return lifetime, nil
+}
+
+// ParseTimestamp parses an RFC3339 timestamp, returning the zero time for an empty string.
+func ParseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimestamp, value)
+	}
+
+	return timestamp, nil
+}
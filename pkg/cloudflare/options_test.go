@@ -0,0 +1,209 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fixedNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTokenOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    TokenOptions
+		wantErr error
+	}{
+		{
+			name: "Empty",
+			opts: TokenOptions{},
+		},
+		{
+			name: "FutureExpiry",
+			opts: TokenOptions{ExpiresOn: fixedNow.Add(time.Hour)},
+		},
+		{
+			name: "NotBeforeOnly",
+			opts: TokenOptions{NotBefore: fixedNow.Add(time.Hour)},
+		},
+		{
+			name: "ValidWindow",
+			opts: TokenOptions{
+				NotBefore: fixedNow.Add(time.Hour),
+				ExpiresOn: fixedNow.Add(2 * time.Hour),
+			},
+		},
+		{
+			name:    "ExpiryInPast",
+			opts:    TokenOptions{ExpiresOn: fixedNow.Add(-time.Hour)},
+			wantErr: ErrExpiryInPast,
+		},
+		{
+			name: "NotBeforeAfterExpiry",
+			opts: TokenOptions{
+				NotBefore: fixedNow.Add(2 * time.Hour),
+				ExpiresOn: fixedNow.Add(time.Hour),
+			},
+			wantErr: ErrNotBeforeAfterExpiry,
+		},
+		{
+			name: "ValidZoneID",
+			opts: TokenOptions{ZoneIDs: []string{"0123456789abcdef0123456789abcdef"}},
+		},
+		{
+			name: "ValidName",
+			opts: TokenOptions{Name: "prod-traefik@ops.example.com"},
+		},
+		{
+			name:    "NameTooLong",
+			opts:    TokenOptions{Name: strings.Repeat("a", MaxTokenNameLength+1)},
+			wantErr: ErrInvalidTokenName,
+		},
+		{
+			name:    "InvalidZoneID",
+			opts:    TokenOptions{ZoneIDs: []string{"example.com"}},
+			wantErr: ErrInvalidZoneID,
+		},
+		{
+			name: "ValidCIDRs",
+			opts: TokenOptions{
+				AllowIPs: []string{"192.0.2.0/24", "2001:db8::/32"},
+				DenyIPs:  []string{"192.0.2.1/32"},
+			},
+		},
+		{
+			name:    "AllowIPWithoutPrefix",
+			opts:    TokenOptions{AllowIPs: []string{"192.0.2.1"}},
+			wantErr: ErrInvalidCIDR,
+		},
+		{
+			name:    "InvalidDenyIP",
+			opts:    TokenOptions{DenyIPs: []string{"2001:db8::/129"}},
+			wantErr: ErrInvalidCIDR,
+		},
+		{
+			name: "AccountPermissions",
+			opts: TokenOptions{
+				AccountID:               "023e105f4ecef8ad9ca31a8372d0c353",
+				AccountPermissionGroups: []string{"workers-write"},
+			},
+		},
+		{
+			name:    "InvalidAccountID",
+			opts:    TokenOptions{AccountID: "Example Account"},
+			wantErr: ErrAccountIDRequired,
+		},
+		{
+			name:    "AccountPermissionsWithoutAccount",
+			opts:    TokenOptions{AccountPermissionGroups: []string{"workers-write"}},
+			wantErr: ErrAccountIDRequired,
+		},
+	}
+
+	origNow := timeNow
+
+	defer func() { timeNow = origNow }()
+
+	timeNow = func() time.Time { return fixedNow }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveExpiry(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn string
+		expiresOn string
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name: "Unset",
+		},
+		{
+			name:      "Hours",
+			expiresIn: "720h",
+			want:      fixedNow.Add(720 * time.Hour),
+		},
+		{
+			name:      "Days",
+			expiresIn: "90d",
+			want:      fixedNow.AddDate(0, 0, 90),
+		},
+		{
+			name:      "Timestamp",
+			expiresOn: "2027-01-01T00:00:00Z",
+			want:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Conflicting",
+			expiresIn: "90d",
+			expiresOn: "2027-01-01T00:00:00Z",
+			wantErr:   ErrConflictingExpiry,
+		},
+		{
+			name:      "InvalidLifetime",
+			expiresIn: "soon",
+			wantErr:   ErrInvalidDuration,
+		},
+		{
+			name:      "NegativeLifetime",
+			expiresIn: "-1h",
+			wantErr:   ErrInvalidDuration,
+		},
+		{
+			name:      "ZeroDays",
+			expiresIn: "0d",
+			wantErr:   ErrInvalidDuration,
+		},
+		{
+			name:      "InvalidTimestamp",
+			expiresOn: "2027-01-01",
+			wantErr:   ErrInvalidTimestamp,
+		},
+	}
+
+	origNow := timeNow
+
+	defer func() { timeNow = origNow }()
+
+	timeNow = func() time.Time { return fixedNow }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveExpiry(tt.expiresIn, tt.expiresOn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveExpiry() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ResolveExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
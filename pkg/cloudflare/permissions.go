@@ -0,0 +1,220 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7/user"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/atomicfile"
+)
+
+// Constants defining the on-disk permission group cache.
+const (
+	// PermissionGroupCacheFile is the name of the permission group cache file.
+	PermissionGroupCacheFile = "permission_groups.json"
+	// PermissionGroupCacheTTL is how long a cached permission group catalog is used
+	// before it is fetched again.
+	PermissionGroupCacheTTL = 24 * time.Hour
+	// zoneScope is the resource scope of permission groups that apply to zones.
+	zoneScope = string(user.TokenPermissionGroupListResponseScopeComCloudflareAPIAccountZone)
+	// cacheDirMode is the permission mode used when creating the cache directory.
+	cacheDirMode = 0o700
+	// cacheFileMode is the permission mode used when writing the cache file.
+	cacheFileMode = 0o600
+)
+
+// PermissionGroup describes a permission group that can be granted to an API token.
+type PermissionGroup struct {
+	// ID is the permission group ID used in token policies.
+	ID string `json:"id"`
+	// Name is the human-readable permission group name, e.g. "DNS Write".
+	Name string `json:"name"`
+	// Category is the product category the permission group belongs to.
+	Category string `json:"category,omitempty"`
+	// Scopes lists the resource scopes the permission group applies to.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// permissionGroupCache is the on-disk representation of the permission group catalog.
+type permissionGroupCache struct {
+	FetchedAt        time.Time         `json:"fetched_at"`
+	PermissionGroups []PermissionGroup `json:"permission_groups"`
+}
+
+// FetchPermissionGroups retrieves the permission group catalog from the Cloudflare API,
+// sorted by name.
+func FetchPermissionGroups(ctx context.Context, api APIInterface) ([]PermissionGroup, error) {
+	// List the permission groups available to user tokens.
+	response, err := api.ListPermissionGroups(ctx, user.TokenPermissionGroupListParams{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListPermissionGroupsFailed, err)
+	}
+
+	// Convert the API response to permission groups.
+	groups := make([]PermissionGroup, 0, len(response))
+
+	for _, r := range response {
+		scopes := make([]string, 0, len(r.Scopes))
+		for _, scope := range r.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+
+		groups = append(groups, PermissionGroup{
+			ID:       r.ID,
+			Name:     r.Name,
+			Category: string(r.Category),
+			Scopes:   scopes,
+		})
+	}
+
+	// Sort the catalog by name for stable output.
+	slices.SortStableFunc(groups, func(a, b PermissionGroup) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return groups, nil
+}
+
+// LoadPermissionGroups returns the permission group catalog, using the cache at cachePath
+// when it is younger than ttl. Otherwise the catalog is fetched from the Cloudflare API and
+// the cache is refreshed. An empty cachePath disables caching.
+func LoadPermissionGroups(
+	ctx context.Context,
+	api APIInterface,
+	cachePath string,
+	ttl time.Duration,
+) ([]PermissionGroup, error) {
+	// Use the cached catalog while it is fresh.
+	if cachePath != "" {
+		cache, err := readPermissionGroupCache(cachePath)
+		if err == nil && timeNow().Sub(cache.FetchedAt) < ttl {
+			return cache.PermissionGroups, nil
+		}
+	}
+
+	// Fetch the catalog from the API.
+	groups, err := FetchPermissionGroups(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh the cache. Failing to write the cache is not fatal.
+	if cachePath != "" {
+		err = writePermissionGroupCache(cachePath, groups)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache permission groups: %v\n", err)
+		}
+	}
+
+	return groups, nil
+}
+
+// readPermissionGroupCache reads the permission group cache from disk.
+func readPermissionGroupCache(path string) (permissionGroupCache, error) {
+	var cache permissionGroupCache
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache, fmt.Errorf("failed to read permission group cache: %w", err)
+	}
+
+	err = json.Unmarshal(data, &cache)
+	if err != nil {
+		return cache, fmt.Errorf("failed to parse permission group cache: %w", err)
+	}
+
+	return cache, nil
+}
+
+// writePermissionGroupCache writes the permission group cache to disk, creating its
+// directory if needed.
+func writePermissionGroupCache(path string, groups []PermissionGroup) error {
+	data, err := json.MarshalIndent(permissionGroupCache{
+		FetchedAt:        timeNow(),
+		PermissionGroups: groups,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode permission group cache: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), cacheDirMode)
+	if err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Replace the cache in one step, so that an interrupted or concurrent run never
+	// leaves a truncated cache behind.
+	err = atomicfile.Write(path, data, cacheFileMode, true)
+	if err != nil {
+		return fmt.Errorf("failed to write permission group cache: %w", err)
+	}
+
+	return nil
+}
+
+// FilterPermissionGroups returns the permission groups whose name contains filter,
+// ignoring case. An empty filter returns every group.
+func FilterPermissionGroups(groups []PermissionGroup, filter string) []PermissionGroup {
+	filter = strings.ToLower(filter)
+
+	return slices.DeleteFunc(slices.Clone(groups), func(group PermissionGroup) bool {
+		return !strings.Contains(strings.ToLower(group.Name), filter)
+	})
+}
+
+// findPermissionGroup looks up a permission group ID by name in the catalog, ignoring case.
+// When a name is shared by groups with different scopes, the zone-scoped group is preferred,
+// as generated tokens grant permissions on zones.
+func findPermissionGroup(catalog []PermissionGroup, name string) (string, bool, error) {
+	var matches []PermissionGroup
+
+	for _, group := range catalog {
+		if strings.EqualFold(group.Name, name) {
+			matches = append(matches, group)
+		}
+	}
+
+	// Narrow multiple matches down to zone-scoped groups, if there are any.
+	if len(matches) > 1 {
+		zoneMatches := slices.DeleteFunc(slices.Clone(matches), func(group PermissionGroup) bool {
+			return !slices.Contains(group.Scopes, zoneScope)
+		})
+		if len(zoneMatches) > 0 {
+			matches = zoneMatches
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return matches[0].ID, true, nil
+	default:
+		return "", false, fmt.Errorf("%w: %q", ErrAmbiguousPermissionGroup, name)
+	}
+}
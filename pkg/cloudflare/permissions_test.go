@@ -0,0 +1,184 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7/user"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
+)
+
+func TestFetchPermissionGroups(t *testing.T) {
+	mockAPI := mocks.NewMockAPIInterface(t)
+	mockAPI.On("ListPermissionGroups", mock.Anything, mock.AnythingOfType("user.TokenPermissionGroupListParams")).
+		Return([]user.TokenPermissionGroupListResponse{
+			{ID: "zone-read", Name: "Zone Read", Category: "dns_and_zones", Scopes: []user.TokenPermissionGroupListResponseScope{"com.cloudflare.api.account.zone"}},
+			{ID: "dns-write", Name: "DNS Write"},
+		}, nil).
+		Once()
+
+	groups, err := FetchPermissionGroups(t.Context(), mockAPI)
+	if err != nil {
+		t.Fatalf("FetchPermissionGroups() error = %v", err)
+	}
+
+	if len(groups) != 2 || groups[0].Name != "DNS Write" || groups[1].Name != "Zone Read" {
+		t.Fatalf("FetchPermissionGroups() = %v, want groups sorted by name", groups)
+	}
+
+	if groups[1].Category != "dns_and_zones" || len(groups[1].Scopes) != 1 || groups[1].Scopes[0] != zoneScope {
+		t.Errorf("FetchPermissionGroups() = %+v, want category and scopes preserved", groups[1])
+	}
+}
+
+func TestLoadPermissionGroups(t *testing.T) {
+	cached := []PermissionGroup{{ID: "cached-id", Name: "Cached"}}
+	fetched := []user.TokenPermissionGroupListResponse{{ID: "fetched-id", Name: "Fetched"}}
+
+	tests := []struct {
+		name      string
+		cacheAge  time.Duration
+		noCache   bool
+		fetchErr  error
+		wantID    string
+		wantFetch bool
+		wantErr   bool
+	}{
+		{
+			name:     "FreshCache",
+			cacheAge: time.Hour,
+			wantID:   "cached-id",
+		},
+		{
+			name:      "StaleCache",
+			cacheAge:  PermissionGroupCacheTTL + time.Hour,
+			wantID:    "fetched-id",
+			wantFetch: true,
+		},
+		{
+			name:      "MissingCache",
+			noCache:   true,
+			wantID:    "fetched-id",
+			wantFetch: true,
+		},
+		{
+			name:      "FetchError",
+			noCache:   true,
+			fetchErr:  errors.New("api error"),
+			wantFetch: true,
+			wantErr:   true,
+		},
+	}
+
+	origNow := timeNow
+
+	defer func() { timeNow = origNow }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cachePath := filepath.Join(t.TempDir(), "cache", PermissionGroupCacheFile)
+
+			// Write a cache entry of the requested age.
+			if !tt.noCache {
+				timeNow = func() time.Time { return fixedNow.Add(-tt.cacheAge) }
+
+				err := writePermissionGroupCache(cachePath, cached)
+				if err != nil {
+					t.Fatalf("writePermissionGroupCache() error = %v", err)
+				}
+			}
+
+			timeNow = func() time.Time { return fixedNow }
+
+			mockAPI := mocks.NewMockAPIInterface(t)
+			if tt.wantFetch {
+				mockAPI.On("ListPermissionGroups", mock.Anything, mock.AnythingOfType("user.TokenPermissionGroupListParams")).
+					Return(fetched, tt.fetchErr).
+					Once()
+			}
+
+			groups, err := LoadPermissionGroups(t.Context(), mockAPI, cachePath, PermissionGroupCacheTTL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPermissionGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(groups) != 1 || groups[0].ID != tt.wantID {
+				t.Errorf("LoadPermissionGroups() = %v, want ID %q", groups, tt.wantID)
+			}
+
+			// A fetched catalog refreshes the cache with owner-only permissions.
+			if tt.wantFetch {
+				cache, err := readPermissionGroupCache(cachePath)
+				if err != nil || len(cache.PermissionGroups) != 1 || cache.PermissionGroups[0].ID != "fetched-id" {
+					t.Errorf("cache = %v (error %v), want refreshed catalog", cache, err)
+				}
+
+				info, err := os.Stat(cachePath)
+				if err != nil || info.Mode().Perm() != cacheFileMode {
+					t.Errorf("cache file mode = %v (error %v), want %v", info.Mode().Perm(), err, os.FileMode(cacheFileMode))
+				}
+
+				// The cache is replaced in one step, leaving no temporary file behind.
+				entries, err := os.ReadDir(filepath.Dir(cachePath))
+				if err != nil || len(entries) != 1 {
+					t.Errorf("cache directory entries = %v (error %v), want only the cache file", entries, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPermissionGroups_NoCachePath(t *testing.T) {
+	mockAPI := mocks.NewMockAPIInterface(t)
+	mockAPI.On("ListPermissionGroups", mock.Anything, mock.AnythingOfType("user.TokenPermissionGroupListParams")).
+		Return([]user.TokenPermissionGroupListResponse{{ID: "fetched-id", Name: "Fetched"}}, nil).
+		Once()
+
+	groups, err := LoadPermissionGroups(t.Context(), mockAPI, "", PermissionGroupCacheTTL)
+	if err != nil || len(groups) != 1 {
+		t.Errorf("LoadPermissionGroups() = %v, %v, want the fetched catalog", groups, err)
+	}
+}
+
+func TestFilterPermissionGroups(t *testing.T) {
+	groups := []PermissionGroup{{Name: "DNS Read"}, {Name: "DNS Write"}, {Name: "Zone Read"}}
+
+	got := FilterPermissionGroups(groups, "dns")
+	if len(got) != 2 || got[0].Name != "DNS Read" || got[1].Name != "DNS Write" {
+		t.Errorf("FilterPermissionGroups() = %v, want the DNS groups", got)
+	}
+
+	if len(groups) != 3 {
+		t.Errorf("FilterPermissionGroups() modified its input: %v", groups)
+	}
+
+	if got := FilterPermissionGroups(groups, ""); len(got) != 3 {
+		t.Errorf("FilterPermissionGroups() with empty filter = %v, want every group", got)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
+)
+
+// Constants naming the preflight checks of the master token.
+const (
+	// CheckTokenActive checks that the master token is known and active.
+	CheckTokenActive = "token active"
+	// CheckZoneAccess checks that the master token can read a zone.
+	CheckZoneAccess = "zone access"
+	// CheckCreateTokens checks that the master token can create API tokens.
+	CheckCreateTokens = "create tokens"
+)
+
+// tokenWriteGroups are the permission groups shown as "API Tokens: Edit" and "Account API
+// Tokens: Edit" in the dashboard, which allow creating user and account-owned tokens.
+var tokenWriteGroups = []string{"API Tokens Write", "Account API Tokens Write"}
+
+// CheckResult reports the outcome of one preflight check of the master token.
+type CheckResult struct {
+	// Name identifies the check, e.g. CheckTokenActive.
+	Name string
+	// Subject is what was checked, such as the zone name, if the check runs more than once.
+	Subject string
+	// Detail describes what was found.
+	Detail string
+	// Err is the reason the check failed, or nil if it passed.
+	Err error
+}
+
+// PreflightResult lists the outcomes of the preflight checks in the order they ran.
+type PreflightResult []CheckResult
+
+// Err returns the error of the first failed check, or nil if every check passed.
+func (r PreflightResult) Err() error {
+	index := slices.IndexFunc(r, func(check CheckResult) bool { return check.Err != nil })
+	if index < 0 {
+		return nil
+	}
+
+	return r[index].Err
+}
+
+// Preflight checks that the master token is active, that it can read each of the named
+// zones, and that it can create API tokens, so that missing permissions are reported
+// before a token is generated. The optional account restricts the zone lookups.
+// The remaining checks are skipped if the token cannot be verified. The Global API Key
+// cannot be verified as a token and grants every permission of its user, so only the
+// zones are checked for it.
+func Preflight(
+	ctx context.Context,
+	api APIInterface,
+	zoneNames []string,
+	account string,
+) PreflightResult {
+	if api.UsesGlobalAPIKey() {
+		result := PreflightResult{{Name: CheckTokenActive, Detail: "skipped, using the Global API Key"}}
+
+		for _, zoneName := range zoneNames {
+			result = append(result, checkZoneAccess(ctx, api, zoneName, account))
+		}
+
+		return append(result, CheckResult{Name: CheckCreateTokens, Detail: "granted by the Global API Key"})
+	}
+
+	// Verify the token first, as every other check depends on it.
+	tokenCheck, tokenID := checkTokenActive(ctx, api)
+
+	result := PreflightResult{tokenCheck}
+	if tokenCheck.Err != nil {
+		return result
+	}
+
+	for _, zoneName := range zoneNames {
+		result = append(result, checkZoneAccess(ctx, api, zoneName, account))
+	}
+
+	return append(result, checkCreateTokens(ctx, api, tokenID))
+}
+
+// checkTokenActive verifies the master token, returning the check result and the token ID.
+func checkTokenActive(ctx context.Context, api APIInterface) (CheckResult, string) {
+	check := CheckResult{Name: CheckTokenActive}
+
+	token, err := api.VerifyAPIToken(ctx)
+	if err != nil {
+		check.Err = fmt.Errorf("%w: %w", ErrTokenUnverified, err)
+
+		return check, ""
+	}
+
+	if token.Status != user.TokenVerifyResponseStatusActive {
+		check.Detail = string(token.Status)
+		check.Err = fmt.Errorf("%w: status is %s", ErrTokenInactive, token.Status)
+
+		return check, token.ID
+	}
+
+	check.Detail = "active, never expires"
+	if !token.ExpiresOn.IsZero() {
+		check.Detail = "active, expires " + token.ExpiresOn.UTC().Format(time.RFC3339)
+	}
+
+	return check, token.ID
+}
+
+// checkZoneAccess checks that the master token can read the named zone.
+func checkZoneAccess(ctx context.Context, api APIInterface, zoneName, account string) CheckResult {
+	check := CheckResult{Name: CheckZoneAccess, Subject: zoneName}
+
+	params := zones.ZoneListParams{Name: cloudflare.F(zoneName)}
+	if account != "" {
+		params.Account = cloudflare.F(zoneListAccount(account))
+	}
+
+	response, err := api.ListZones(ctx, params)
+	if err != nil {
+		check.Err = fmt.Errorf("%w: %w", ErrListZonesFailed, err)
+
+		return check
+	}
+
+	if len(response.Result) == 0 {
+		check.Err = fmt.Errorf("%w: %s", ErrZoneNotAccessible, zoneName)
+
+		return check
+	}
+
+	ids := make([]string, 0, len(response.Result))
+	for _, zone := range response.Result {
+		ids = append(ids, zone.ID)
+	}
+
+	check.Detail = "zone " + strings.Join(ids, ", ")
+
+	return check
+}
+
+// checkCreateTokens checks that the master token can create API tokens. Listing tokens
+// requires at least read access to them, and the master token's own policies show
+// whether it can also write them. If the master token is not among the listed tokens,
+// its permissions cannot be confirmed and the check passes.
+func checkCreateTokens(ctx context.Context, api APIInterface, tokenID string) CheckResult {
+	check := CheckResult{Name: CheckCreateTokens}
+
+	tokens, err := FindTokens(ctx, api, MatchTokenID(tokenID))
+	if err != nil {
+		check.Err = fmt.Errorf("%w: %w", ErrMissingTokenPermission, err)
+
+		return check
+	}
+
+	if len(tokens) == 0 {
+		check.Detail = "could not confirm the permissions of the master token"
+
+		return check
+	}
+
+	if !grantsTokenWrite(tokens[0]) {
+		check.Err = ErrMissingTokenPermission
+
+		return check
+	}
+
+	check.Detail = "granted API Tokens: Edit"
+
+	return check
+}
+
+// grantsTokenWrite reports whether a token's policies allow it to write API tokens.
+func grantsTokenWrite(token shared.Token) bool {
+	for _, policy := range token.Policies {
+		if policy.Effect != shared.TokenPolicyEffectAllow {
+			continue
+		}
+
+		for _, group := range policy.PermissionGroups {
+			if slices.ContainsFunc(tokenWriteGroups, func(name string) bool {
+				return strings.EqualFold(group.Name, name)
+			}) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
+)
+
+// masterToken returns the master token as listed by the API, granted the given permission groups.
+func masterToken(groups ...string) shared.Token {
+	permissionGroups := make([]shared.TokenPolicyPermissionGroup, 0, len(groups))
+	for _, name := range groups {
+		permissionGroups = append(permissionGroups, shared.TokenPolicyPermissionGroup{Name: name})
+	}
+
+	return shared.Token{
+		ID: "master-id",
+		Policies: []shared.TokenPolicy{{
+			Effect:           shared.TokenPolicyEffectAllow,
+			PermissionGroups: permissionGroups,
+		}},
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	active := &user.TokenVerifyResponse{ID: "master-id", Status: user.TokenVerifyResponseStatusActive}
+	zonePage := &pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}
+
+	tests := []struct {
+		name       string
+		zoneNames  []string
+		globalKey  bool
+		setupMock  func(m *mocks.MockAPIInterface)
+		wantChecks []string
+		wantErr    error
+	}{
+		{
+			name:      "AllChecksPass",
+			zoneNames: []string{"example.com"},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).
+					Return(&user.TokenVerifyResponse{
+						ID:        "master-id",
+						Status:    user.TokenVerifyResponseStatusActive,
+						ExpiresOn: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+					}, nil).
+					Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(zonePage, nil).
+					Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{masterToken("Zone Read", "API Tokens Write")}, nil).
+					Once()
+			},
+			wantChecks: []string{
+				"token active: active, expires 2027-01-01T00:00:00Z",
+				"zone access example.com: zone zone-id-123",
+				"create tokens: granted API Tokens: Edit",
+			},
+		},
+		{
+			name: "VerifyError",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).
+					Return(nil, errors.New("invalid token")).
+					Once()
+			},
+			wantChecks: []string{"token active: "},
+			wantErr:    ErrTokenUnverified,
+		},
+		{
+			name: "TokenExpired",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).
+					Return(&user.TokenVerifyResponse{ID: "master-id", Status: user.TokenVerifyResponseStatusExpired}, nil).
+					Once()
+			},
+			wantChecks: []string{"token active: expired"},
+			wantErr:    ErrTokenInactive,
+		},
+		{
+			name:      "ZoneNotAccessible",
+			zoneNames: []string{"example.org"},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).Return(active, nil).Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{}, nil).
+					Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{masterToken("API Tokens Write")}, nil).
+					Once()
+			},
+			wantChecks: []string{
+				"token active: active, never expires",
+				"zone access example.org: ",
+				"create tokens: granted API Tokens: Edit",
+			},
+			wantErr: ErrZoneNotAccessible,
+		},
+		{
+			name: "AccountTokenPermission",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).Return(active, nil).Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{masterToken("Account API Tokens Write")}, nil).
+					Once()
+			},
+			wantChecks: []string{"token active: active, never expires", "create tokens: granted API Tokens: Edit"},
+		},
+		{
+			name: "CannotListTokens",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).Return(active, nil).Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(nil, errors.New("forbidden")).
+					Once()
+			},
+			wantChecks: []string{"token active: active, never expires", "create tokens: "},
+			wantErr:    ErrMissingTokenPermission,
+		},
+		{
+			name: "ReadOnlyTokenPermission",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).Return(active, nil).Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{masterToken("API Tokens Read")}, nil).
+					Once()
+			},
+			wantChecks: []string{"token active: active, never expires", "create tokens: "},
+			wantErr:    ErrMissingTokenPermission,
+		},
+		{
+			name: "MasterTokenNotListed",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("VerifyAPIToken", mock.Anything).Return(active, nil).Once()
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{}, nil).
+					Once()
+			},
+			wantChecks: []string{
+				"token active: active, never expires",
+				"create tokens: could not confirm the permissions of the master token",
+			},
+		},
+		{
+			name:      "GlobalAPIKey",
+			zoneNames: []string{"example.com"},
+			globalKey: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(zonePage, nil).
+					Once()
+			},
+			wantChecks: []string{
+				"token active: skipped, using the Global API Key",
+				"zone access example.com: zone zone-id-123",
+				"create tokens: granted by the Global API Key",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			mockAPI.On("UsesGlobalAPIKey").Return(tt.globalKey).Once()
+			tt.setupMock(mockAPI)
+
+			result := Preflight(t.Context(), mockAPI, tt.zoneNames, "")
+
+			if len(result) != len(tt.wantChecks) {
+				t.Fatalf("Preflight() returned %d checks, want %d: %+v", len(result), len(tt.wantChecks), result)
+			}
+
+			for i, check := range result {
+				name := check.Name
+				if check.Subject != "" {
+					name += " " + check.Subject
+				}
+
+				if got := name + ": " + check.Detail; got != tt.wantChecks[i] {
+					t.Errorf("Preflight()[%d] = %q, want %q", i, got, tt.wantChecks[i])
+				}
+			}
+
+			err := result.Err()
+			if (err != nil) != (tt.wantErr != nil) || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("Preflight().Err() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
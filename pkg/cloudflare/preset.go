@@ -0,0 +1,165 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Constants defining additional Cloudflare permission group IDs used by the built-in presets.
+const (
+	// DNSReadPermission grants read access to DNS records.
+	DNSReadPermission = "82e64a83756745bbbb1c9c2701bf816b"
+	// CachePurgePermission grants access to purge a zone's cache.
+	CachePurgePermission = "e17beae8b8cb423a99b1730f21238bed"
+	// SSLWritePermission grants write access to SSL and certificate settings.
+	SSLWritePermission = "c03055bc037c4ea9afb9a9f104b7b721"
+	// ZoneSettingsWritePermission grants write access to zone settings.
+	ZoneSettingsWritePermission = "3030687196b94b638145a3953da2b699"
+)
+
+// DefaultPreset is the permission preset used when none is selected.
+const DefaultPreset = "dns-edit"
+
+// idPattern matches the format of Cloudflare identifiers, such as permission group,
+// zone, and account IDs.
+var idPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// knownPermissionGroups maps the names of common permission groups to their IDs,
+// allowing them to be resolved without fetching the permission group catalog.
+var knownPermissionGroups = map[string]string{
+	"Zone Read":                  ZoneReadPermission,
+	"DNS Read":                   DNSReadPermission,
+	"DNS Write":                  DNSWritePermission,
+	"Cache Purge":                CachePurgePermission,
+	"SSL and Certificates Write": SSLWritePermission,
+	"Zone Settings Write":        ZoneSettingsWritePermission,
+}
+
+// builtinPresets maps the built-in preset names to the permission groups they grant.
+// Every preset includes zone read access so that clients can look up the zone.
+var builtinPresets = map[string][]string{
+	"dns-edit":           {ZoneReadPermission, DNSWritePermission},
+	"dns-read":           {ZoneReadPermission, DNSReadPermission},
+	"cache-purge":        {ZoneReadPermission, CachePurgePermission},
+	"ssl-edit":           {ZoneReadPermission, SSLWritePermission},
+	"zone-settings-edit": {ZoneReadPermission, ZoneSettingsWritePermission},
+}
+
+// PresetNames returns the sorted names of the built-in and custom presets.
+func PresetNames(custom map[string][]string) []string {
+	names := slices.Collect(maps.Keys(builtinPresets))
+
+	for name := range custom {
+		if _, ok := builtinPresets[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// PresetPermissionGroups returns the permission groups granted by the named preset,
+// as listed in its definition. Custom presets take precedence over built-in presets
+// with the same name, and their entries may be permission group IDs or names.
+func PresetPermissionGroups(name string, custom map[string][]string) ([]string, error) {
+	// Look up the preset, preferring custom definitions.
+	groups, ok := custom[name]
+	if !ok {
+		groups, ok = builtinPresets[name]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: %q (available: %s)",
+			ErrUnknownPreset,
+			name,
+			strings.Join(PresetNames(custom), ", "),
+		)
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrEmptyPreset, name)
+	}
+
+	return groups, nil
+}
+
+// NeedsPermissionCatalog reports whether any of the permission groups is a name that
+// can only be resolved using the Cloudflare permission group catalog.
+func NeedsPermissionCatalog(groups []string) bool {
+	return slices.ContainsFunc(groups, func(group string) bool {
+		_, err := ResolvePermissionGroup(group, nil)
+
+		return err != nil
+	})
+}
+
+// ResolvePermissionGroups resolves permission group IDs or names to IDs.
+// See ResolvePermissionGroup for how each entry is resolved.
+func ResolvePermissionGroups(groups []string, catalog []PermissionGroup) ([]string, error) {
+	ids := make([]string, 0, len(groups))
+
+	for _, group := range groups {
+		id, err := ResolvePermissionGroup(group, catalog)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ResolvePermissionGroup returns the ID of a permission group given either its ID or its
+// name. Names are matched case-insensitively against the catalog, falling back to the
+// well-known groups used by the built-in presets when the catalog is not available.
+func ResolvePermissionGroup(group string, catalog []PermissionGroup) (string, error) {
+	group = strings.TrimSpace(group)
+
+	// Accept permission group IDs as-is.
+	if idPattern.MatchString(group) {
+		return group, nil
+	}
+
+	// Look up the permission group by name in the catalog.
+	id, ok, err := findPermissionGroup(catalog, group)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		return id, nil
+	}
+
+	// Look up the ID of a well-known permission group by name.
+	for name, id := range knownPermissionGroups {
+		if strings.EqualFold(name, group) {
+			return id, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownPermissionGroup, group)
+}
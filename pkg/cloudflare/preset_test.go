@@ -0,0 +1,166 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPresetPermissionGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  string
+		custom  map[string][]string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "DefaultPreset",
+			preset: DefaultPreset,
+			want:   []string{ZoneReadPermission, DNSWritePermission},
+		},
+		{
+			name:   "BuiltinCachePurge",
+			preset: "cache-purge",
+			want:   []string{ZoneReadPermission, CachePurgePermission},
+		},
+		{
+			name:   "Custom",
+			preset: "certs",
+			custom: map[string][]string{"certs": {"Zone Read", "SSL and Certificates Write"}},
+			want:   []string{"Zone Read", "SSL and Certificates Write"},
+		},
+		{
+			name:   "CustomOverridesBuiltin",
+			preset: "dns-edit",
+			custom: map[string][]string{"dns-edit": {"DNS Write"}},
+			want:   []string{"DNS Write"},
+		},
+		{
+			name:    "UnknownPreset",
+			preset:  "everything",
+			wantErr: ErrUnknownPreset,
+		},
+		{
+			name:    "EmptyPreset",
+			preset:  "empty",
+			custom:  map[string][]string{"empty": {}},
+			wantErr: ErrEmptyPreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PresetPermissionGroups(tt.preset, tt.custom)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PresetPermissionGroups() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("PresetPermissionGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresetNames(t *testing.T) {
+	got := PresetNames(map[string][]string{"ci": {"Cache Purge"}, "dns-edit": {"DNS Write"}})
+	want := []string{"cache-purge", "ci", "dns-edit", "dns-read", "ssl-edit", "zone-settings-edit"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("PresetNames() = %v, want %v", got, want)
+	}
+}
+
+func TestResolvePermissionGroups(t *testing.T) {
+	catalog := []PermissionGroup{
+		{ID: "11111111111111111111111111111111", Name: "Workers Scripts Write", Scopes: []string{"com.cloudflare.api.account"}},
+		{ID: "22222222222222222222222222222222", Name: "Analytics Read", Scopes: []string{"com.cloudflare.api.account"}},
+		{ID: "33333333333333333333333333333333", Name: "Analytics Read", Scopes: []string{"com.cloudflare.api.account.zone"}},
+		{ID: "44444444444444444444444444444444", Name: "Logs Read", Scopes: []string{"com.cloudflare.api.account"}},
+		{ID: "55555555555555555555555555555555", Name: "Logs Read", Scopes: []string{"com.cloudflare.api.user"}},
+	}
+
+	tests := []struct {
+		name    string
+		groups  []string
+		catalog []PermissionGroup
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "IDs",
+			groups: []string{"0123456789abcdef0123456789abcdef"},
+			want:   []string{"0123456789abcdef0123456789abcdef"},
+		},
+		{
+			name:   "WellKnownNamesWithoutCatalog",
+			groups: []string{"zone read", " DNS Write "},
+			want:   []string{ZoneReadPermission, DNSWritePermission},
+		},
+		{
+			name:    "CatalogName",
+			groups:  []string{"workers scripts write"},
+			catalog: catalog,
+			want:    []string{"11111111111111111111111111111111"},
+		},
+		{
+			name:    "PrefersZoneScope",
+			groups:  []string{"Analytics Read"},
+			catalog: catalog,
+			want:    []string{"33333333333333333333333333333333"},
+		},
+		{
+			name:    "Ambiguous",
+			groups:  []string{"Logs Read"},
+			catalog: catalog,
+			wantErr: ErrAmbiguousPermissionGroup,
+		},
+		{
+			name:    "Unknown",
+			groups:  []string{"Everything Admin"},
+			catalog: catalog,
+			wantErr: ErrUnknownPermissionGroup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePermissionGroups(tt.groups, tt.catalog)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolvePermissionGroups() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ResolvePermissionGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsPermissionCatalog(t *testing.T) {
+	if NeedsPermissionCatalog([]string{ZoneReadPermission, "DNS Write"}) {
+		t.Error("NeedsPermissionCatalog() = true for IDs and well-known names, want false")
+	}
+
+	if !NeedsPermissionCatalog([]string{"Zone Read", "Workers Scripts Write"}) {
+		t.Error("NeedsPermissionCatalog() = false for an unknown name, want true")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+)
+
+// TokenMatcher reports whether an API token should be selected.
+type TokenMatcher func(token shared.Token) bool
+
+// MatchAllTokens is a TokenMatcher that selects every token.
+func MatchAllTokens(_ shared.Token) bool {
+	return true
+}
+
+// MatchTokenID returns a TokenMatcher that selects the token with the given ID.
+func MatchTokenID(tokenID string) TokenMatcher {
+	return func(token shared.Token) bool {
+		return token.ID == tokenID
+	}
+}
+
+// MatchTokenName returns a TokenMatcher that selects tokens with the given name.
+func MatchTokenName(name string) TokenMatcher {
+	return func(token shared.Token) bool {
+		return token.Name == name
+	}
+}
+
+// MatchServiceZones returns a TokenMatcher that selects tokens generated for the given
+// service in any of the zones, i.e. tokens named "service.<zone>" or "service.<zone>,<zone>"
+// where every zone is one of zones, by name or ID. Checking the zones keeps tokens of
+// other services whose names start with "service." from being selected.
+func MatchServiceZones(serviceName string, zones []string) TokenMatcher {
+	prefix := TokenName(serviceName, "")
+
+	return func(token shared.Token) bool {
+		zoneList, found := strings.CutPrefix(token.Name, prefix)
+		if !found || zoneList == "" {
+			return false
+		}
+
+		for zone := range strings.SplitSeq(zoneList, ",") {
+			if !slices.Contains(zones, zone) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// RevokeToken deletes the API token with the given ID.
+func RevokeToken(ctx context.Context, api APIInterface, tokenID string) error {
+	// Delete the API token. Failures already wrap ErrDeleteTokenFailed.
+	return api.DeleteAPIToken(ctx, tokenID)
+}
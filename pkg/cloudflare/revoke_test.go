@@ -0,0 +1,221 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
+)
+
+func TestTokenMatchers(t *testing.T) {
+	token := shared.Token{ID: "token-id-123", Name: "traefik.example.com"}
+
+	tests := []struct {
+		name    string
+		matcher TokenMatcher
+		want    bool
+	}{
+		{name: "AllTokens", matcher: MatchAllTokens, want: true},
+		{name: "IDMatch", matcher: MatchTokenID("token-id-123"), want: true},
+		{name: "IDMismatch", matcher: MatchTokenID("token-id-456")},
+		{name: "NameMatch", matcher: MatchTokenName("traefik.example.com"), want: true},
+		{name: "NameMismatch", matcher: MatchTokenName("traefik.example.org")},
+		{name: "ServiceZonesMatch", matcher: MatchServiceZones("traefik", []string{"example.com"}), want: true},
+		{name: "ServiceZonesMismatch", matcher: MatchServiceZones("caddy", []string{"example.com"})},
+		{name: "ServiceZonesPartialName", matcher: MatchServiceZones("traef", []string{"example.com"})},
+		{name: "ServiceZonesUnknownZone", matcher: MatchServiceZones("traefik", []string{"example.org"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher(token); got != tt.want {
+				t.Errorf("matcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchServiceZones(t *testing.T) {
+	names := []string{
+		"traefik.example.com",
+		"traefik.example.org",
+		"traefik.example.com,example.org",
+		"traefik.internal.example.com",
+		"traefik.",
+	}
+	zones := []string{"example.com", "example.org"}
+
+	tests := []struct {
+		name        string
+		serviceName string
+		want        []string
+	}{
+		{
+			name:        "Service",
+			serviceName: "traefik",
+			want:        []string{"traefik.example.com", "traefik.example.org", "traefik.example.com,example.org"},
+		},
+		{
+			name:        "DottedService",
+			serviceName: "traefik.internal",
+			want:        []string{"traefik.internal.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := MatchServiceZones(tt.serviceName, zones)
+
+			var got []string
+
+			for _, name := range names {
+				if match(shared.Token{Name: name}) {
+					got = append(got, name)
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matched names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTokens(t *testing.T) {
+	tokens := []shared.Token{
+		{ID: "token-1", Name: "traefik.example.com"},
+		{ID: "token-2", Name: "caddy.example.com"},
+		{ID: "token-3", Name: "traefik.example.org"},
+	}
+
+	tests := []struct {
+		name      string
+		matcher   TokenMatcher
+		wantIDs   []string
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:    "ServiceZones",
+			matcher: MatchServiceZones("traefik", []string{"example.com", "example.org"}),
+			wantIDs: []string{"token-1", "token-3"},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(tokens, nil).
+					Once()
+			},
+		},
+		{
+			name:    "NoMatches",
+			matcher: MatchTokenName("certbot.example.com"),
+			wantIDs: []string{},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(tokens, nil).
+					Once()
+			},
+		},
+		{
+			name:    "ListError",
+			matcher: MatchAllTokens,
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(nil, errors.New("list error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			got, err := FindTokens(t.Context(), mockAPI, tt.matcher)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindTokens() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("FindTokens() returned %d tokens, want %d", len(got), len(tt.wantIDs))
+			}
+
+			for i, token := range got {
+				if token.ID != tt.wantIDs[i] {
+					t.Errorf("FindTokens()[%d].ID = %q, want %q", i, token.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name: "Success",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("DeleteAPIToken", mock.Anything, "token-id-123").
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:    "DeleteError",
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("DeleteAPIToken", mock.Anything, "token-id-123").
+					Return(fmt.Errorf("%w: delete error", ErrDeleteTokenFailed)).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			err := RevokeToken(t.Context(), mockAPI, "token-id-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RevokeToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrDeleteTokenFailed) {
+				t.Errorf("RevokeToken() error = %v, want %v", err, ErrDeleteTokenFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// RotateToken rolls the secret of the existing API token generated for the specified
+// service, selected by match. The token keeps its ID and policies; only its value changes.
+// It returns the new token value.
+func RotateToken(
+	ctx context.Context,
+	serviceName string,
+	match TokenMatcher,
+	api APIInterface,
+) (string, error) {
+	// Find the existing token of the service.
+	tokens, err := FindTokens(ctx, api, match)
+	if err != nil {
+		return "", err
+	}
+
+	// Require exactly one matching token.
+	switch len(tokens) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrTokenNotFound, serviceName)
+	case 1:
+	default:
+		return "", fmt.Errorf("%w: %s", ErrMultipleTokensFound, serviceName)
+	}
+
+	// Log token rotation intent.
+	fmt.Fprintln(os.Stderr, "Rotating API token:", tokens[0].Name)
+
+	// Roll the token secret. Failures already wrap ErrRollTokenFailed.
+	value, err := api.RollAPIToken(ctx, tokens[0].ID)
+	if err != nil {
+		return "", err
+	}
+
+	// Return the new token value.
+	return value, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7/shared"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
+)
+
+func TestRotateToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantValue string
+		wantErr   error
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:      "Success",
+			wantValue: "rolled-value",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{
+						{ID: "token-id-123", Name: "test-service.example.com"},
+						{ID: "token-id-456", Name: "other.example.com"},
+					}, nil).
+					Once()
+				m.On("RollAPIToken", mock.Anything, "token-id-123").
+					Return("rolled-value", nil).
+					Once()
+			},
+		},
+		{
+			name:    "NotFound",
+			wantErr: ErrTokenNotFound,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{{ID: "token-id-456", Name: "other.example.com"}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "MultipleTokens",
+			wantErr: ErrMultipleTokensFound,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{
+						{ID: "token-id-123", Name: "test-service.example.com"},
+						{ID: "token-id-789", Name: "test-service.example.com"},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "ListError",
+			wantErr: ErrListTokensFailed,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return(nil, fmt.Errorf("%w: list error", ErrListTokensFailed)).
+					Once()
+			},
+		},
+		{
+			name:    "RollError",
+			wantErr: ErrRollTokenFailed,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+					Return([]shared.Token{{ID: "token-id-123", Name: "test-service.example.com"}}, nil).
+					Once()
+				m.On("RollAPIToken", mock.Anything, "token-id-123").
+					Return("", fmt.Errorf("%w: roll error", ErrRollTokenFailed)).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			oldStderr := os.Stderr
+			_, w, _ := os.Pipe()
+			os.Stderr = w
+
+			defer func() { os.Stderr = oldStderr }()
+
+			gotValue, err := RotateToken(
+				t.Context(),
+				"test-service",
+				MatchTokenName(TokenName("test-service", "example.com")),
+				mockAPI,
+			)
+
+			w.Close()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RotateToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if gotValue != tt.wantValue {
+				t.Errorf("RotateToken() = %q, want %q", gotValue, tt.wantValue)
+			}
+		})
+	}
+}
@@ -21,13 +21,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 )
 
-// Constants defining Cloudflare permission IDs for zone read and DNS write.
+// Constants defining Cloudflare permission IDs for zone read and DNS write,
+// granted by the DefaultPreset.
 const (
 	// ZoneReadPermission grants read access to Cloudflare zones.
 	ZoneReadPermission = "c8fed203ed3043cba015a93ad1616f1f"
@@ -35,45 +39,199 @@ const (
 	DNSWritePermission = "4755a26eedb94da69e1066d98aa820be"
 )
 
-// GenerateTokenFunc generates a Cloudflare API token, defaulting to GenerateToken.
-var GenerateTokenFunc = GenerateToken
+// ZoneResourcePrefix is the policy resource key prefix that identifies a Cloudflare zone.
+const ZoneResourcePrefix = "com.cloudflare.api.account.zone."
 
-// GenerateToken creates a new Cloudflare API token for the specified service and zone.
-// It retrieves the zone ID, configures token policies, and returns the token ID.
+// GeneratedToken describes a newly created API token.
+type GeneratedToken struct {
+	// ID is the token identifier.
+	ID string `json:"id" yaml:"id"`
+	// Name is the token name.
+	Name string `json:"name" yaml:"name"`
+	// Value is the token secret. It is only available when the token is created.
+	Value string `json:"value" yaml:"value"`
+	// ZoneIDs lists the IDs of the zones the token grants permissions on.
+	ZoneIDs []string `json:"zone_ids" yaml:"zone_ids"`
+	// AccountID is the ID of the account owning the token, or empty for a token owned by the user.
+	AccountID string `json:"account_id,omitempty" yaml:"account_id,omitempty"`
+	// Permissions lists the IDs of the permission groups granted by the token.
+	Permissions []string `json:"permissions" yaml:"permissions"`
+	// ExpiresOn is the time at which the token expires, or zero if it never expires.
+	ExpiresOn time.Time `json:"expires_on,omitzero" yaml:"expires_on,omitempty"`
+	// NotBefore is the time before which the token is not valid, or zero if it is valid immediately.
+	NotBefore time.Time `json:"not_before,omitzero" yaml:"not_before,omitempty"`
+	// IssuedOn is the time at which the token was created.
+	IssuedOn time.Time `json:"issued_on" yaml:"issued_on"`
+	// ReplacedIDs lists the IDs of the existing tokens with the same name that were deleted.
+	ReplacedIDs []string `json:"replaced_ids,omitempty" yaml:"replaced_ids,omitempty"`
+}
+
+// TokenPlan describes the token that GenerateToken would create, without creating it.
+type TokenPlan struct {
+	// Name is the token name.
+	Name string
+	// ZoneIDs lists the IDs of the zones the token grants permissions on.
+	ZoneIDs []string
+	// Permissions lists the IDs of the permission groups granted by the token.
+	Permissions []string
+	// Params holds the exact parameters sent to Cloudflare to create the token.
+	Params user.TokenNewParams
+}
+
+var (
+	// GenerateTokenFunc generates a Cloudflare API token, defaulting to GenerateToken.
+	GenerateTokenFunc = GenerateToken
+	// PlanTokenFunc plans a Cloudflare API token, defaulting to PlanToken.
+	PlanTokenFunc = PlanToken
+)
+
+// GenerateToken creates a new Cloudflare API token for the specified service and zones.
+// It plans the token with PlanToken and returns a description of the created token
+// including its value.
+//
+// Existing tokens with the same name are handled according to opts.Duplicates. When they
+// are replaced but cannot be deleted, the new token is returned along with an error
+// wrapping ErrReplaceTokenFailed, so that its value is not lost.
 func GenerateToken(
 	ctx context.Context,
-	serviceName, zoneName string,
+	serviceName string,
+	zoneNames []string,
+	client *Client,
+	api APIInterface,
+	opts TokenOptions,
+) (GeneratedToken, error) {
+	// Build the token parameters, resolving the zones.
+	plan, err := PlanToken(ctx, serviceName, zoneNames, client, api, opts)
+	if err != nil {
+		return GeneratedToken{}, err
+	}
+
+	// Look for existing tokens with the same name, unless duplicates are allowed.
+	existing, err := existingTokenIDs(ctx, api, plan.Name, opts.Duplicates)
+	if err != nil {
+		return GeneratedToken{}, err
+	}
+
+	// Log token generation intent.
+	fmt.Fprintln(os.Stderr, "Generating API token:", plan.Name)
+
+	// Create the API token.
+	token, err := api.CreateAPIToken(ctx, plan.Params)
+	if err != nil {
+		return GeneratedToken{}, fmt.Errorf("%w: %w", ErrCreateTokenFailed, err)
+	}
+
+	// Describe the generated token, including its value.
+	generated := GeneratedToken{
+		ID:          token.ID,
+		Name:        plan.Name,
+		Value:       token.Value,
+		ZoneIDs:     plan.ZoneIDs,
+		AccountID:   opts.AccountID,
+		Permissions: plan.Permissions,
+		ExpiresOn:   token.ExpiresOn,
+		NotBefore:   token.NotBefore,
+		IssuedOn:    token.IssuedOn,
+	}
+
+	// Delete the replaced tokens now that the new token exists.
+	for _, tokenID := range existing {
+		fmt.Fprintln(os.Stderr, "Deleting replaced API token:", tokenID)
+
+		err = RevokeToken(ctx, api, tokenID)
+		if err != nil {
+			return generated, fmt.Errorf("%w %s: %w", ErrReplaceTokenFailed, tokenID, err)
+		}
+
+		generated.ReplacedIDs = append(generated.ReplacedIDs, tokenID)
+	}
+
+	return generated, nil
+}
+
+// PlanToken builds the parameters of a new Cloudflare API token for the specified service
+// and zones without creating it. It validates the token options and name, retrieves the
+// zone IDs (unless opts.Offline is set), and configures a token policy covering every
+// zone, and another covering the account for the permission groups that apply to accounts.
+func PlanToken(
+	ctx context.Context,
+	serviceName string,
+	zoneNames []string,
 	client *Client,
 	api APIInterface,
-) (string, error) {
-	// Retrieve the zone ID for the given zone name.
-	zoneID, err := client.GetZoneID(ctx, zoneName, api)
+	opts TokenOptions,
+) (TokenPlan, error) {
+	// Validate the token options before making any API calls.
+	err := opts.Validate()
 	if err != nil {
-		return "", fmt.Errorf("%w: %w", ErrGetZoneIDFailed, err)
+		return TokenPlan{}, err
 	}
 
-	// Construct the token name from service and zone names.
-	tokenName := serviceName + "." + zoneName
+	// Use the configured token name, or construct it from service and zone names,
+	// or zone IDs if no names are given.
+	tokenName := opts.Name
+	if tokenName == "" {
+		zoneLabel := zoneNames
+		if len(zoneLabel) == 0 {
+			zoneLabel = opts.ZoneIDs
+		}
 
-	// Define permissions for zone read and DNS write.
-	permissions := []shared.TokenPolicyPermissionGroupParam{{
-		ID: cloudflare.F(ZoneReadPermission),
-	}, {
-		ID: cloudflare.F(DNSWritePermission),
-	}}
+		tokenName = TokenName(serviceName, ZoneListName(zoneLabel))
+	}
 
-	// Specify resources to apply permissions to the zone.
-	resources := shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam{
-		"com.cloudflare.api.account.zone." + zoneID: "*",
+	// Check the name however it was built, as a token covering many zones may exceed
+	// the length limit.
+	err = ValidateTokenName(tokenName)
+	if err != nil {
+		return TokenPlan{}, err
 	}
+
+	// Determine the IDs of the zones to grant permissions on.
+	zoneIDs, err := resolveZoneIDs(ctx, zoneNames, client, api, opts)
+	if err != nil {
+		return TokenPlan{}, err
+	}
+
+	// Specify resources to apply permissions to each zone, keyed by zone ID.
+	resources := shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam{}
+	for _, zoneID := range zoneIDs {
+		resources[ZoneResourcePrefix+zoneID] = "*"
+	}
+
 	resourcesUnion := shared.TokenPolicyResourcesUnionParam(resources)
 
-	// Configure token policy to allow the specified permissions and resources.
-	policies := []shared.TokenPolicyParam{{
-		Effect:           cloudflare.F(shared.TokenPolicyEffectAllow),
-		PermissionGroups: cloudflare.F(permissions),
-		Resources:        cloudflare.F(resourcesUnion),
-	}}
+	// Define the permissions granted on the zones, defaulting to the DefaultPreset.
+	groups := opts.PermissionGroups
+	if len(groups) == 0 {
+		groups = builtinPresets[DefaultPreset]
+	}
+
+	// Grant the permission groups that apply to accounts on the account, and the others
+	// on the zones.
+	var zoneGroups, accountGroups []string
+
+	for _, id := range groups {
+		if slices.Contains(opts.AccountPermissionGroups, id) {
+			accountGroups = append(accountGroups, id)
+		} else {
+			zoneGroups = append(zoneGroups, id)
+		}
+	}
+
+	// Configure token policies to allow the specified permissions and resources.
+	var policies []shared.TokenPolicyParam
+
+	if len(zoneGroups) > 0 {
+		policies = append(policies, allowPolicy(zoneGroups, resourcesUnion))
+	}
+
+	if len(accountGroups) > 0 {
+		accountResources := shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam{
+			AccountResourcePrefix + opts.AccountID: "*",
+		}
+
+		policies = append(policies, allowPolicy(accountGroups, shared.TokenPolicyResourcesUnionParam(accountResources)))
+	}
 
 	// Set up parameters for creating the new token.
 	params := user.TokenNewParams{
@@ -81,15 +239,161 @@ func GenerateToken(
 		Policies: cloudflare.F(policies),
 	}
 
-	// Log token generation intent.
-	fmt.Fprintln(os.Stdout, "Generating API token:", tokenName)
+	// Apply the optional validity window.
+	if !opts.ExpiresOn.IsZero() {
+		params.ExpiresOn = cloudflare.F(opts.ExpiresOn)
+	}
 
-	// Create the API token.
-	token, err := api.CreateAPIToken(ctx, params)
+	if !opts.NotBefore.IsZero() {
+		params.NotBefore = cloudflare.F(opts.NotBefore)
+	}
+
+	// Restrict the client IP ranges the token may be used from.
+	if len(opts.AllowIPs) > 0 || len(opts.DenyIPs) > 0 {
+		params.Condition = cloudflare.F(requestIPCondition(opts.AllowIPs, opts.DenyIPs))
+	}
+
+	return TokenPlan{
+		Name:        tokenName,
+		ZoneIDs:     zoneIDs,
+		Permissions: groups,
+		Params:      params,
+	}, nil
+}
+
+// allowPolicy builds a token policy allowing the permission groups on the resources.
+func allowPolicy(groups []string, resources shared.TokenPolicyResourcesUnionParam) shared.TokenPolicyParam {
+	permissions := make([]shared.TokenPolicyPermissionGroupParam, 0, len(groups))
+	for _, id := range groups {
+		permissions = append(permissions, shared.TokenPolicyPermissionGroupParam{
+			ID: cloudflare.F(id),
+		})
+	}
+
+	return shared.TokenPolicyParam{
+		Effect:           cloudflare.F(shared.TokenPolicyEffectAllow),
+		PermissionGroups: cloudflare.F(permissions),
+		Resources:        cloudflare.F(resources),
+	}
+}
+
+// existingTokenIDs returns the IDs of the existing tokens named tokenName that are to be
+// replaced. It returns ErrTokenAlreadyExists if such tokens exist and may not be replaced,
+// and does not list tokens at all if duplicates are allowed.
+func existingTokenIDs(
+	ctx context.Context,
+	api APIInterface,
+	tokenName string,
+	policy DuplicatePolicy,
+) ([]string, error) {
+	if policy == DuplicateAllow {
+		return nil, nil
+	}
+
+	tokens, err := FindTokens(ctx, api, MatchTokenName(tokenName))
 	if err != nil {
-		return "", fmt.Errorf("%w: %w", ErrCreateTokenFailed, err)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+	}
+
+	if len(ids) > 0 && policy != DuplicateReplace {
+		return nil, fmt.Errorf("%w: %s (ID %s)", ErrTokenAlreadyExists, tokenName, strings.Join(ids, ", "))
+	}
+
+	return ids, nil
+}
+
+// resolveZoneIDs returns the IDs of the zones a token is generated for.
+// Zone IDs given in the options are used without looking them up, unless zone names
+// are also given, in which case each name must resolve to the ID at the same position.
+// Otherwise the ID of each zone name is looked up. In offline mode, zone IDs are
+// required and never checked against the names.
+func resolveZoneIDs(
+	ctx context.Context,
+	zoneNames []string,
+	client *Client,
+	api APIInterface,
+	opts TokenOptions,
+) ([]string, error) {
+	// Require at least one zone to grant permissions on.
+	if len(zoneNames) == 0 && len(opts.ZoneIDs) == 0 {
+		return nil, ErrNoZones
+	}
+
+	// Use the given zone IDs as-is when there are no names to check them against.
+	if len(zoneNames) == 0 {
+		return opts.ZoneIDs, nil
+	}
+
+	if len(opts.ZoneIDs) > 0 && len(opts.ZoneIDs) != len(zoneNames) {
+		return nil, fmt.Errorf(
+			"%w: %d zone names but %d zone IDs",
+			ErrZoneIDMismatch,
+			len(zoneNames),
+			len(opts.ZoneIDs),
+		)
+	}
+
+	// Without API access, zone names cannot be looked up or checked against the IDs.
+	if opts.Offline {
+		if len(opts.ZoneIDs) == 0 {
+			return nil, ErrOfflineZoneLookup
+		}
+
+		return opts.ZoneIDs, nil
+	}
+
+	// Look up the ID of each zone, checking it against the given ID if any.
+	zoneIDs := make([]string, 0, len(zoneNames))
+
+	for i, zoneName := range zoneNames {
+		zoneID, err := client.GetZoneID(ctx, zoneName, opts.Account, api)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetZoneIDFailed, err)
+		}
+
+		if len(opts.ZoneIDs) > 0 && opts.ZoneIDs[i] != zoneID {
+			return nil, fmt.Errorf(
+				"%w: zone %s has ID %s, not %s",
+				ErrZoneIDMismatch,
+				zoneName,
+				zoneID,
+				opts.ZoneIDs[i],
+			)
+		}
+
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+
+	return zoneIDs, nil
+}
+
+// ZoneListName joins zone names for use in a token name, e.g. "example.com,example.org".
+func ZoneListName(zoneNames []string) string {
+	return strings.Join(zoneNames, ",")
+}
+
+// TokenName builds the conventional token name for a service in a zone, e.g. "service.example.com".
+func TokenName(serviceName, zoneName string) string {
+	return serviceName + "." + zoneName
+}
+
+// requestIPCondition builds the token condition restricting client IP ranges.
+// Empty lists are left unset so that they are omitted from the request.
+func requestIPCondition(allow, deny []string) user.TokenNewParamsCondition {
+	var requestIP user.TokenNewParamsConditionRequestIP
+
+	if len(allow) > 0 {
+		requestIP.In = cloudflare.F(allow)
+	}
+
+	if len(deny) > 0 {
+		requestIP.NotIn = cloudflare.F(deny)
 	}
 
-	// Return the generated token’s Value.
-	return token.Value, nil
+	return user.TokenNewParamsCondition{RequestIP: cloudflare.F(requestIP)}
 }
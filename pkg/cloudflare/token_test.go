@@ -19,11 +19,17 @@ package cloudflare
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/shared"
 	"github.com/cloudflare/cloudflare-go/v7/user"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 	"github.com/stretchr/testify/mock"
@@ -31,12 +37,195 @@ import (
 	"github.com/nicholas-fedor/gogeneratecftoken/pkg/cloudflare/mocks"
 )
 
+func TestGenerateToken_MultipleZones(t *testing.T) {
+	mockAPI := mocks.NewMockAPIInterface(t)
+	mockAPI.On("ListZones", mock.Anything, mock.MatchedBy(func(p zones.ZoneListParams) bool {
+		return p.Name.Value == "example.com"
+	})).
+		Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-1", Name: "example.com"}}}, nil).
+		Once()
+	mockAPI.On("ListZones", mock.Anything, mock.MatchedBy(func(p zones.ZoneListParams) bool {
+		return p.Name.Value == "example.org"
+	})).
+		Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-2", Name: "example.org"}}}, nil).
+		Once()
+	expectNoExistingTokens(mockAPI)
+	mockAPI.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+		resources, ok := p.Policies.Value[0].Resources.Value.(shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam)
+
+		return p.Name.Value == "certs.example.com,example.org" &&
+			len(p.Policies.Value) == 1 &&
+			ok &&
+			maps.Equal(resources, shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam{
+				ZoneResourcePrefix + "zone-id-1": "*",
+				ZoneResourcePrefix + "zone-id-2": "*",
+			})
+	})).
+		Return(&user.TokenNewResponse{ID: "token-id-123", Value: "multi-zone-value", IssuedOn: fixedNow}, nil).
+		Once()
+
+	client := &Client{Client: &cloudflare.Client{}}
+
+	oldStderr := os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stderr = w
+
+	defer func() { os.Stderr = oldStderr }()
+
+	got, err := GenerateToken(t.Context(), "certs", []string{"example.com", "example.org"}, client, mockAPI, TokenOptions{})
+
+	w.Close()
+
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	want := GeneratedToken{
+		ID:          "token-id-123",
+		Name:        "certs.example.com,example.org",
+		Value:       "multi-zone-value",
+		ZoneIDs:     []string{"zone-id-1", "zone-id-2"},
+		Permissions: []string{ZoneReadPermission, DNSWritePermission},
+		IssuedOn:    fixedNow,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateToken() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerateToken_NoZones(t *testing.T) {
+	client := &Client{Client: &cloudflare.Client{}}
+
+	_, err := GenerateToken(t.Context(), "certs", nil, client, mocks.NewMockAPIInterface(t), TokenOptions{})
+	if !errors.Is(err, ErrNoZones) {
+		t.Errorf("GenerateToken() error = %v, want %v", err, ErrNoZones)
+	}
+}
+
+func TestResolveZoneIDs(t *testing.T) {
+	const (
+		zoneIDCom = "0123456789abcdef0123456789abcdef"
+		zoneIDOrg = "fedcba9876543210fedcba9876543210"
+	)
+
+	tests := []struct {
+		name      string
+		zoneNames []string
+		zoneIDs   []string
+		want      []string
+		wantErr   error
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:    "IDsOnlySkipLookup",
+			zoneIDs: []string{zoneIDCom, zoneIDOrg},
+			want:    []string{zoneIDCom, zoneIDOrg},
+		},
+		{
+			name:      "NamesOnly",
+			zoneNames: []string{"example.com"},
+			want:      []string{zoneIDCom},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: zoneIDCom}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:      "MatchingNamesAndIDs",
+			zoneNames: []string{"example.com"},
+			zoneIDs:   []string{zoneIDCom},
+			want:      []string{zoneIDCom},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: zoneIDCom}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:      "MismatchedNameAndID",
+			zoneNames: []string{"example.com"},
+			zoneIDs:   []string{zoneIDOrg},
+			wantErr:   ErrZoneIDMismatch,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: zoneIDCom}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:      "DifferentCounts",
+			zoneNames: []string{"example.com", "example.org"},
+			zoneIDs:   []string{zoneIDCom},
+			wantErr:   ErrZoneIDMismatch,
+		},
+		{
+			name:    "NoZones",
+			wantErr: ErrNoZones,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockAPI)
+			}
+
+			client := &Client{Client: &cloudflare.Client{}}
+
+			got, err := resolveZoneIDs(t.Context(), tt.zoneNames, client, mockAPI, TokenOptions{ZoneIDs: tt.zoneIDs})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveZoneIDs() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("resolveZoneIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateToken_ZoneIDsOnly(t *testing.T) {
+	mockAPI := mocks.NewMockAPIInterface(t)
+	expectNoExistingTokens(mockAPI)
+	mockAPI.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+		return p.Name.Value == "certs.0123456789abcdef0123456789abcdef"
+	})).
+		Return(&user.TokenNewResponse{Value: "zone-id-value"}, nil).
+		Once()
+
+	client := &Client{Client: &cloudflare.Client{}}
+
+	oldStderr := os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stderr = w
+
+	defer func() { os.Stderr = oldStderr }()
+
+	got, err := GenerateToken(
+		t.Context(),
+		"certs",
+		nil,
+		client,
+		mockAPI,
+		TokenOptions{ZoneIDs: []string{"0123456789abcdef0123456789abcdef"}},
+	)
+
+	w.Close()
+
+	if err != nil || got.Value != "zone-id-value" {
+		t.Errorf("GenerateToken() = %+v, %v, want value %q", got, err, "zone-id-value")
+	}
+}
+
 func TestGenerateToken(t *testing.T) {
 	tests := []struct {
 		name        string
 		serviceName string
 		zone        string
 		zoneID      string
+		opts        TokenOptions
 		wantToken   string
 		wantErr     bool
 		setupMock   func(m *mocks.MockAPIInterface)
@@ -56,6 +245,114 @@ func TestGenerateToken(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			name:        "WithValidityWindow",
+			serviceName: "test-service",
+			zone:        "example.com",
+			zoneID:      "zone-id-123",
+			opts: TokenOptions{
+				ExpiresOn: time.Date(2099, 1, 2, 0, 0, 0, 0, time.UTC),
+				NotBefore: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantToken: "abcdefghijklmnopqrstuvwxyz1234567890ABCD",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+				m.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+					return p.ExpiresOn.Value.Equal(time.Date(2099, 1, 2, 0, 0, 0, 0, time.UTC)) &&
+						p.NotBefore.Value.Equal(time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC))
+				})).
+					Return(&user.TokenNewResponse{Value: "abcdefghijklmnopqrstuvwxyz1234567890ABCD"}, nil).
+					Once()
+			},
+		},
+		{
+			name:        "WithIPRestrictions",
+			serviceName: "test-service",
+			zone:        "example.com",
+			zoneID:      "zone-id-123",
+			opts: TokenOptions{
+				AllowIPs: []string{"192.0.2.0/24"},
+				DenyIPs:  []string{"192.0.2.1/32"},
+			},
+			wantToken: "abcdefghijklmnopqrstuvwxyz1234567890ABCD",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+				m.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+					requestIP := p.Condition.Value.RequestIP.Value
+
+					return slices.Equal(requestIP.In.Value, []string{"192.0.2.0/24"}) &&
+						slices.Equal(requestIP.NotIn.Value, []string{"192.0.2.1/32"})
+				})).
+					Return(&user.TokenNewResponse{Value: "abcdefghijklmnopqrstuvwxyz1234567890ABCD"}, nil).
+					Once()
+			},
+		},
+		{
+			name:        "WithPermissionGroups",
+			serviceName: "test-service",
+			zone:        "example.com",
+			zoneID:      "zone-id-123",
+			opts:        TokenOptions{PermissionGroups: []string{CachePurgePermission}},
+			wantToken:   "abcdefghijklmnopqrstuvwxyz1234567890ABCD",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+				m.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+					groups := p.Policies.Value[0].PermissionGroups.Value
+
+					return len(groups) == 1 && groups[0].ID.Value == CachePurgePermission
+				})).
+					Return(&user.TokenNewResponse{Value: "abcdefghijklmnopqrstuvwxyz1234567890ABCD"}, nil).
+					Once()
+			},
+		},
+		{
+			name:        "WithName",
+			serviceName: "test-service",
+			zone:        "example.com",
+			zoneID:      "zone-id-123",
+			opts:        TokenOptions{Name: "prod-traefik@ops.example.com"},
+			wantToken:   "abcdefghijklmnopqrstuvwxyz1234567890ABCD",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+				m.On("CreateAPIToken", mock.Anything, mock.MatchedBy(func(p user.TokenNewParams) bool {
+					return p.Name.Value == "prod-traefik@ops.example.com"
+				})).
+					Return(&user.TokenNewResponse{Value: "abcdefghijklmnopqrstuvwxyz1234567890ABCD"}, nil).
+					Once()
+			},
+		},
+		{
+			name:        "InvalidName",
+			serviceName: "test-service",
+			zone:        "example.com",
+			opts:        TokenOptions{Name: "line\nbreak"},
+			wantErr:     true,
+			setupMock:   func(_ *mocks.MockAPIInterface) {},
+		},
+		{
+			name:        "InvalidAllowIP",
+			serviceName: "test-service",
+			zone:        "example.com",
+			opts:        TokenOptions{AllowIPs: []string{"not-a-cidr"}},
+			wantErr:     true,
+			setupMock:   func(_ *mocks.MockAPIInterface) {},
+		},
+		{
+			name:        "ExpiryInPast",
+			serviceName: "test-service",
+			zone:        "example.com",
+			opts:        TokenOptions{ExpiresOn: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr:     true,
+			setupMock:   func(_ *mocks.MockAPIInterface) {},
+		},
 		{
 			name:        "ListZonesError",
 			serviceName: "test-service",
@@ -110,21 +407,23 @@ func TestGenerateToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAPI := mocks.NewMockAPIInterface(t)
 			tt.setupMock(mockAPI)
+			expectNoExistingTokens(mockAPI)
 
 			client := &Client{Client: &cloudflare.Client{}}
 
-			oldStdout := os.Stdout
+			oldStderr := os.Stderr
 			r, w, _ := os.Pipe()
-			os.Stdout = w
+			os.Stderr = w
 
-			defer func() { os.Stdout = oldStdout }()
+			defer func() { os.Stderr = oldStderr }()
 
 			gotToken, err := GenerateToken(
 				t.Context(),
 				tt.serviceName,
-				tt.zone,
+				[]string{tt.zone},
 				client,
 				mockAPI,
+				tt.opts,
 			)
 
 			w.Close()
@@ -140,18 +439,279 @@ func TestGenerateToken(t *testing.T) {
 			}
 
 			if !tt.wantErr {
-				if gotToken != tt.wantToken {
-					t.Errorf("GenerateToken() = %q, want %q", gotToken, tt.wantToken)
+				if gotToken.Value != tt.wantToken {
+					t.Errorf("GenerateToken() value = %q, want %q", gotToken.Value, tt.wantToken)
+				}
+
+				wantName := tt.opts.Name
+				if wantName == "" {
+					wantName = tt.serviceName + "." + tt.zone
 				}
 
-				if output != "Generating API token: "+tt.serviceName+"."+tt.zone+"\n" {
+				if gotToken.Name != wantName {
+					t.Errorf("GenerateToken() name = %q, want %q", gotToken.Name, wantName)
+				}
+
+				if output != "Generating API token: "+wantName+"\n" {
 					t.Errorf(
 						"GenerateToken() output = %q, want %q",
 						output,
-						"Generating API token: "+tt.serviceName+"."+tt.zone+"\n",
+						"Generating API token: "+wantName+"\n",
 					)
 				}
 			}
 		})
 	}
 }
+
+// expectNoExistingTokens sets up the token listing used to detect duplicates to find no tokens.
+func expectNoExistingTokens(m *mocks.MockAPIInterface) {
+	m.On("ListAPITokens", mock.Anything, mock.AnythingOfType("user.TokenListParams")).
+		Return([]shared.Token{}, nil).
+		Maybe()
+}
+
+func TestGenerateToken_Duplicates(t *testing.T) {
+	existing := []shared.Token{
+		{ID: "old-token-1", Name: "traefik.example.com"},
+		{ID: "other-token", Name: "other.example.com"},
+		{ID: "old-token-2", Name: "traefik.example.com"},
+	}
+
+	tests := []struct {
+		name         string
+		policy       DuplicatePolicy
+		setupMock    func(m *mocks.MockAPIInterface)
+		wantValue    string
+		wantReplaced []string
+		wantErr      error
+	}{
+		{
+			name:   "FailOnExisting",
+			policy: DuplicateFail,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.Anything).Return(existing, nil).Once()
+			},
+			wantErr: ErrTokenAlreadyExists,
+		},
+		{
+			name:   "FailWithoutExisting",
+			policy: DuplicateFail,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.Anything).Return(existing[1:2], nil).Once()
+				m.On("CreateAPIToken", mock.Anything, mock.Anything).
+					Return(&user.TokenNewResponse{ID: "new-token", Value: "new-value"}, nil).
+					Once()
+			},
+			wantValue: "new-value",
+		},
+		{
+			name:   "ListError",
+			policy: DuplicateFail,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: forbidden", ErrListTokensFailed)).
+					Once()
+			},
+			wantErr: ErrListTokensFailed,
+		},
+		{
+			name:   "Replace",
+			policy: DuplicateReplace,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.Anything).Return(existing, nil).Once()
+				m.On("CreateAPIToken", mock.Anything, mock.Anything).
+					Return(&user.TokenNewResponse{ID: "new-token", Value: "new-value"}, nil).
+					Once()
+				m.On("DeleteAPIToken", mock.Anything, "old-token-1").Return(nil).Once()
+				m.On("DeleteAPIToken", mock.Anything, "old-token-2").Return(nil).Once()
+			},
+			wantValue:    "new-value",
+			wantReplaced: []string{"old-token-1", "old-token-2"},
+		},
+		{
+			name:   "ReplaceDeleteError",
+			policy: DuplicateReplace,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListAPITokens", mock.Anything, mock.Anything).Return(existing, nil).Once()
+				m.On("CreateAPIToken", mock.Anything, mock.Anything).
+					Return(&user.TokenNewResponse{ID: "new-token", Value: "new-value"}, nil).
+					Once()
+				m.On("DeleteAPIToken", mock.Anything, "old-token-1").Return(errors.New("forbidden")).Once()
+			},
+			wantValue: "new-value",
+			wantErr:   ErrReplaceTokenFailed,
+		},
+		{
+			name:   "AllowDuplicate",
+			policy: DuplicateAllow,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("CreateAPIToken", mock.Anything, mock.Anything).
+					Return(&user.TokenNewResponse{ID: "new-token", Value: "new-value"}, nil).
+					Once()
+			},
+			wantValue: "new-value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			client := &Client{Client: &cloudflare.Client{}}
+
+			oldStderr := os.Stderr
+			_, w, _ := os.Pipe()
+			os.Stderr = w
+
+			defer func() { os.Stderr = oldStderr }()
+
+			got, err := GenerateToken(
+				t.Context(),
+				"traefik",
+				nil,
+				client,
+				mockAPI,
+				TokenOptions{
+					Name:       "traefik.example.com",
+					ZoneIDs:    []string{"0123456789abcdef0123456789abcdef"},
+					Duplicates: tt.policy,
+				},
+			)
+
+			w.Close()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got.Value != tt.wantValue {
+				t.Errorf("GenerateToken() value = %q, want %q", got.Value, tt.wantValue)
+			}
+
+			if !slices.Equal(got.ReplacedIDs, tt.wantReplaced) {
+				t.Errorf("GenerateToken() replaced = %v, want %v", got.ReplacedIDs, tt.wantReplaced)
+			}
+		})
+	}
+}
+
+func TestPlanToken_Offline(t *testing.T) {
+	tests := []struct {
+		name      string
+		zoneNames []string
+		opts      TokenOptions
+		wantName  string
+		wantErr   error
+	}{
+		{
+			name:      "NamesAndIDs",
+			zoneNames: []string{"example.com"},
+			opts:      TokenOptions{Offline: true, ZoneIDs: []string{"023e105f4ecef8ad9ca31a8372d0c353"}},
+			wantName:  "certs.example.com",
+		},
+		{
+			name:     "IDsOnly",
+			opts:     TokenOptions{Offline: true, ZoneIDs: []string{"023e105f4ecef8ad9ca31a8372d0c353"}},
+			wantName: "certs.023e105f4ecef8ad9ca31a8372d0c353",
+		},
+		{
+			name:      "NamesWithoutIDs",
+			zoneNames: []string{"example.com"},
+			opts:      TokenOptions{Offline: true},
+			wantErr:   ErrOfflineZoneLookup,
+		},
+		{
+			name: "DefaultNameTooLong",
+			zoneNames: []string{
+				"first-rather-long-zone-name.example.com",
+				"second-rather-long-zone-name.example.com",
+				"third-rather-long-zone-name.example.com",
+			},
+			opts:    TokenOptions{Offline: true},
+			wantErr: ErrInvalidTokenName,
+		},
+		{
+			name:      "MismatchedCounts",
+			zoneNames: []string{"example.com", "example.org"},
+			opts:      TokenOptions{Offline: true, ZoneIDs: []string{"023e105f4ecef8ad9ca31a8372d0c353"}},
+			wantErr:   ErrZoneIDMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No client or API is given, so any API call would panic.
+			plan, err := PlanToken(t.Context(), "certs", tt.zoneNames, nil, nil, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlanToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if plan.Name != tt.wantName || plan.Params.Name.Value != tt.wantName {
+				t.Errorf("PlanToken() name = %q (params %q), want %q", plan.Name, plan.Params.Name.Value, tt.wantName)
+			}
+
+			if !slices.Equal(plan.ZoneIDs, tt.opts.ZoneIDs) {
+				t.Errorf("PlanToken() zone IDs = %v, want %v", plan.ZoneIDs, tt.opts.ZoneIDs)
+			}
+
+			if !slices.Equal(plan.Permissions, []string{ZoneReadPermission, DNSWritePermission}) {
+				t.Errorf("PlanToken() permissions = %v, want the default preset", plan.Permissions)
+			}
+		})
+	}
+}
+
+func TestPlanToken_AccountPermissions(t *testing.T) {
+	const (
+		zoneID    = "023e105f4ecef8ad9ca31a8372d0c353"
+		accountID = "0123456789abcdef0123456789abcdef"
+	)
+
+	opts := TokenOptions{
+		Offline:                 true,
+		ZoneIDs:                 []string{zoneID},
+		PermissionGroups:        []string{"dns-write", "workers-write"},
+		AccountID:               accountID,
+		AccountPermissionGroups: []string{"workers-write"},
+	}
+
+	plan, err := PlanToken(t.Context(), "certs", nil, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("PlanToken() error = %v", err)
+	}
+
+	policies := plan.Params.Policies.Value
+	if len(policies) != 2 {
+		t.Fatalf("PlanToken() policies = %d, want 2", len(policies))
+	}
+
+	tests := []struct {
+		name         string
+		policy       shared.TokenPolicyParam
+		wantGroup    string
+		wantResource string
+	}{
+		{name: "Zone", policy: policies[0], wantGroup: "dns-write", wantResource: ZoneResourcePrefix + zoneID},
+		{name: "Account", policy: policies[1], wantGroup: "workers-write", wantResource: AccountResourcePrefix + accountID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := tt.policy.PermissionGroups.Value
+			if len(groups) != 1 || groups[0].ID.Value != tt.wantGroup {
+				t.Errorf("PlanToken() %s policy groups = %v, want [%s]", tt.name, groups, tt.wantGroup)
+			}
+
+			resources, ok := tt.policy.Resources.Value.(shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam)
+			if !ok || len(resources) != 1 || resources[tt.wantResource] != "*" {
+				t.Errorf("PlanToken() %s policy resources = %v, want %s", tt.name, tt.policy.Resources.Value, tt.wantResource)
+			}
+		})
+	}
+}
@@ -18,19 +18,35 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cloudflare
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 )
 
+// zonesPerPage is the number of zones requested per page when listing all zones.
+const zonesPerPage = 50
+
 // GetZoneID retrieves the ID of a Cloudflare zone by its name.
-// It returns an error if no zone, multiple zones, or a listing error occurs.
-func (c *Client) GetZoneID(ctx context.Context, zoneName string, api APIInterface) (string, error) {
-	// Set up parameters to filter zones by name.
+// The optional account, given as a name or ID, restricts the search to zones in that
+// account. It returns an error if no zone, multiple zones, or a listing error occurs;
+// the error for multiple zones lists the candidates with their accounts.
+func (c *Client) GetZoneID(
+	ctx context.Context,
+	zoneName, account string,
+	api APIInterface,
+) (string, error) {
+	// Set up parameters to filter zones by name and account.
 	params := zones.ZoneListParams{Name: cloudflare.F(zoneName)}
 
+	if account != "" {
+		params.Account = cloudflare.F(zoneListAccount(account))
+	}
+
 	// List zones matching the name.
 	response, err := api.ListZones(ctx, params)
 	if err != nil {
@@ -47,6 +63,107 @@ func (c *Client) GetZoneID(ctx context.Context, zoneName string, api APIInterfac
 		return response.Result[0].ID, nil
 	default:
 		// Multiple zones found, which is ambiguous.
-		return "", fmt.Errorf("%w: %s", ErrMultipleZonesFound, zoneName)
+		return "", fmt.Errorf(
+			"%w: %s; set an account to choose one of:\n%s",
+			ErrMultipleZonesFound,
+			zoneName,
+			describeZones(response.Result),
+		)
+	}
+}
+
+// zoneListAccount builds the account filter for listing zones, treating the value as
+// an account ID if it has the format of one and as an account name otherwise.
+func zoneListAccount(account string) zones.ZoneListParamsAccount {
+	if idPattern.MatchString(account) {
+		return zones.ZoneListParamsAccount{ID: cloudflare.F(account)}
+	}
+
+	return zones.ZoneListParamsAccount{Name: cloudflare.F(account)}
+}
+
+// describeZones formats zones with their IDs and accounts, one per line.
+func describeZones(candidates []zones.Zone) string {
+	lines := make([]string, 0, len(candidates))
+
+	for _, zone := range candidates {
+		lines = append(lines, fmt.Sprintf(
+			"  %s (zone %s) in account %q (%s)",
+			zone.Name,
+			zone.ID,
+			zone.Account.Name,
+			zone.Account.ID,
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ZoneSummary describes a Cloudflare zone and the account that owns it.
+type ZoneSummary struct {
+	// ID is the zone identifier.
+	ID string
+	// Name is the zone name, e.g. "example.com".
+	Name string
+	// AccountID is the identifier of the account owning the zone.
+	AccountID string
+	// AccountName is the name of the account owning the zone.
+	AccountName string
+}
+
+// ListAllZones retrieves every zone visible to the client, sorted by name.
+// It pages through the zone listing and returns an error if any page fails to load.
+func ListAllZones(ctx context.Context, api APIInterface) ([]ZoneSummary, error) {
+	var summaries []ZoneSummary
+
+	for page := 1; ; page++ {
+		// Set up parameters to fetch the current page of zones.
+		params := zones.ZoneListParams{
+			Page:    cloudflare.F(float64(page)),
+			PerPage: cloudflare.F(float64(zonesPerPage)),
+		}
+
+		// List zones on the current page.
+		response, err := api.ListZones(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListZonesFailed, err)
+		}
+
+		// Summarize each zone.
+		for _, zone := range response.Result {
+			summaries = append(summaries, ZoneSummary{
+				ID:          zone.ID,
+				Name:        zone.Name,
+				AccountID:   zone.Account.ID,
+				AccountName: zone.Account.Name,
+			})
+		}
+
+		// Stop once a short page indicates there are no more zones.
+		if len(response.Result) < zonesPerPage {
+			break
+		}
 	}
+
+	slices.SortFunc(summaries, func(a, b ZoneSummary) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.AccountName, b.AccountName))
+	})
+
+	return summaries, nil
+}
+
+// GetZoneNames retrieves the names of all zones visible to the client, keyed by zone ID.
+// It pages through the zone listing and returns an error if any page fails to load.
+func (c *Client) GetZoneNames(ctx context.Context, api APIInterface) (map[string]string, error) {
+	summaries, err := ListAllZones(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(summaries))
+	for _, zone := range summaries {
+		names[zone.ID] = zone.Name
+	}
+
+	return names, nil
 }
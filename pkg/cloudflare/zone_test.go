@@ -19,6 +19,9 @@ package cloudflare
 
 import (
 	"errors"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go/v7"
@@ -31,11 +34,13 @@ import (
 
 func TestGetZoneID(t *testing.T) {
 	tests := []struct {
-		name      string
-		zone      string
-		wantID    string
-		wantErr   bool
-		setupMock func(m *mocks.MockAPIInterface)
+		name       string
+		zone       string
+		account    string
+		wantID     string
+		wantErr    bool
+		wantErrMsg []string
+		setupMock  func(m *mocks.MockAPIInterface)
 	}{
 		{
 			name:   "Success",
@@ -67,6 +72,53 @@ func TestGetZoneID(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			name:    "AccountName",
+			zone:    "example.com",
+			account: "Production",
+			wantID:  "zone-id-prod",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.MatchedBy(func(p zones.ZoneListParams) bool {
+					return p.Name.Value == "example.com" &&
+						p.Account.Value.Name.Value == "Production" &&
+						!p.Account.Value.ID.Present
+				})).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-prod"}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "AccountID",
+			zone:    "example.com",
+			account: "0123456789abcdef0123456789abcdef",
+			wantID:  "zone-id-prod",
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.MatchedBy(func(p zones.ZoneListParams) bool {
+					return p.Account.Value.ID.Value == "0123456789abcdef0123456789abcdef" &&
+						!p.Account.Value.Name.Present
+				})).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-prod"}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "MultipleZonesListsCandidates",
+			zone:    "example.com",
+			wantErr: true,
+			wantErrMsg: []string{
+				ErrMultipleZonesFound.Error(),
+				`example.com (zone zone-staging) in account "Staging" (acct-staging)`,
+				`example.com (zone zone-prod) in account "Production" (acct-prod)`,
+			},
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{
+						{ID: "zone-staging", Name: "example.com", Account: zones.ZoneAccount{ID: "acct-staging", Name: "Staging"}},
+						{ID: "zone-prod", Name: "example.com", Account: zones.ZoneAccount{ID: "acct-prod", Name: "Production"}},
+					}}, nil).
+					Once()
+			},
+		},
 		{
 			name:    "ListError",
 			zone:    "example.com",
@@ -88,16 +140,126 @@ func TestGetZoneID(t *testing.T) {
 			mockAPI := mocks.NewMockAPIInterface(t)
 			tt.setupMock(mockAPI)
 
-			gotID, err := client.GetZoneID(t.Context(), tt.zone, mockAPI)
+			gotID, err := client.GetZoneID(t.Context(), tt.zone, tt.account, mockAPI)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetZoneID() error = %v, wantErr %v", err, tt.wantErr)
 
 				return
 			}
 
+			for _, want := range tt.wantErrMsg {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("GetZoneID() error = %q, want it to contain %q", err, want)
+				}
+			}
+
 			if !tt.wantErr && gotID != tt.wantID {
 				t.Errorf("GetZoneID() = %q, want %q", gotID, tt.wantID)
 			}
 		})
 	}
 }
+
+func TestGetZoneNames(t *testing.T) {
+	fullPage := make([]zones.Zone, zonesPerPage)
+	for i := range fullPage {
+		fullPage[i] = zones.Zone{ID: "zone-" + strconv.Itoa(i), Name: "example" + strconv.Itoa(i) + ".com"}
+	}
+
+	tests := []struct {
+		name      string
+		wantNames map[string]string
+		wantCount int
+		wantErr   bool
+		setupMock func(m *mocks.MockAPIInterface)
+	}{
+		{
+			name:      "SinglePage",
+			wantNames: map[string]string{"zone-id-123": "example.com"},
+			wantCount: 1,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-id-123", Name: "example.com"}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:      "MultiplePages",
+			wantNames: map[string]string{"zone-0": "example0.com", "zone-last": "last.com"},
+			wantCount: zonesPerPage + 1,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: fullPage}, nil).
+					Once()
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{{ID: "zone-last", Name: "last.com"}}}, nil).
+					Once()
+			},
+		},
+		{
+			name:    "ListError",
+			wantErr: true,
+			setupMock: func(m *mocks.MockAPIInterface) {
+				m.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+					Return(nil, errors.New("list error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				Client: &cloudflare.Client{},
+			}
+
+			mockAPI := mocks.NewMockAPIInterface(t)
+			tt.setupMock(mockAPI)
+
+			gotNames, err := client.GetZoneNames(t.Context(), mockAPI)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetZoneNames() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(gotNames) != tt.wantCount {
+				t.Errorf("GetZoneNames() returned %d zones, want %d", len(gotNames), tt.wantCount)
+			}
+
+			for id, name := range tt.wantNames {
+				if gotNames[id] != name {
+					t.Errorf("GetZoneNames()[%q] = %q, want %q", id, gotNames[id], name)
+				}
+			}
+		})
+	}
+}
+
+func TestListAllZones(t *testing.T) {
+	mockAPI := mocks.NewMockAPIInterface(t)
+	mockAPI.On("ListZones", mock.Anything, mock.AnythingOfType("zones.ZoneListParams")).
+		Return(&pagination.V4PagePaginationArray[zones.Zone]{Result: []zones.Zone{
+			{ID: "zone-2", Name: "example.org", Account: zones.ZoneAccount{ID: "account-2", Name: "Work"}},
+			{ID: "zone-1", Name: "example.com", Account: zones.ZoneAccount{ID: "account-1", Name: "Personal"}},
+		}}, nil).
+		Once()
+
+	got, err := ListAllZones(t.Context(), mockAPI)
+	if err != nil {
+		t.Fatalf("ListAllZones() error = %v", err)
+	}
+
+	want := []ZoneSummary{
+		{ID: "zone-1", Name: "example.com", AccountID: "account-1", AccountName: "Personal"},
+		{ID: "zone-2", Name: "example.org", AccountID: "account-2", AccountName: "Work"},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("ListAllZones() = %+v, want %+v", got, want)
+	}
+}
@@ -18,8 +18,10 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,6 +51,9 @@ var (
 
 	// osExit terminates the program with an exit code, defaulting to os.Exit.
 	osExit = os.Exit
+
+	// loadErr is the error that kept the configuration file from being loaded, if any.
+	loadErr error
 )
 
 // Viper defines the interface for configuration management, wrapping viper.Viper methods.
@@ -80,8 +85,20 @@ type Viper interface {
 	// ReadInConfig loads the configuration from the specified file.
 	ReadInConfig() error
 
+	// ReadConfig replaces the loaded configuration with one read from in.
+	ReadConfig(in io.Reader) error
+
 	// ConfigFileUsed returns the path of the loaded configuration file.
 	ConfigFileUsed() string
+
+	// GetString returns the value of a configuration key as a string.
+	GetString(key string) string
+
+	// GetStringMap returns the value of a configuration key as a map.
+	GetStringMap(key string) map[string]any
+
+	// MergeConfigMap merges settings into the loaded configuration.
+	MergeConfigMap(cfg map[string]any) error
 }
 
 // InitConfig initializes the configuration using Viper.
@@ -94,7 +111,9 @@ func InitConfig() {
 		InitConfigFunc = initConfig
 	}
 
-	// Execute the initialization function.
+	// Execute the initialization function, clearing the error of any previous load.
+	loadErr = nil
+
 	InitConfigFunc(viperInstance)
 }
 
@@ -111,8 +130,14 @@ func initConfig(cfg Viper) {
 	// Configure environment variable bindings.
 	setEnv(cfg)
 
-	// Load the configuration file.
-	loadConfig(cfg)
+	// Load the configuration file, keeping the error for the commands that need it.
+	loadErr = loadConfig(cfg)
+}
+
+// LoadError returns the error that kept the configuration file from being loaded, such
+// as a failure to decrypt it. Commands that check the file itself can still run.
+func LoadError() error {
+	return loadErr
 }
 
 // setConfigFile configures Viper with the configuration file path or default locations.
@@ -124,15 +149,12 @@ func setConfigFile(cfg Viper) error {
 		cfg.SetConfigFile(ConfigFile)
 
 	default:
-		// Determine user's home directory for default config path.
-		homeDir, err := osUserHomeDir()
+		// Determine the default config path in the user's home directory.
+		configPath, err := AppDir()
 		if err != nil {
-			return fmt.Errorf("failed to get user home directory: %w", err)
+			return err
 		}
 
-		// Construct default config path in home directory.
-		configPath := filepath.Join(homeDir, AppDirName)
-
 		// Add config paths: home directory and current directory.
 		cfg.AddConfigPath(configPath)
 		cfg.AddConfigPath(".")
@@ -145,6 +167,17 @@ func setConfigFile(cfg Viper) error {
 	return nil
 }
 
+// AppDir returns the application directory in the user's home directory, which holds
+// the default configuration file and cached data.
+func AppDir() (string, error) {
+	homeDir, err := osUserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, AppDirName), nil
+}
+
 // setEnv configures Viper to bind environment variables with defaults.
 func setEnv(cfg Viper) {
 	// Set environment variable prefix to "CF".
@@ -161,8 +194,9 @@ func setEnv(cfg Viper) {
 	cfg.SetDefault("zone", "")
 }
 
-// loadConfig attempts to load the configuration file and reports its status.
-func loadConfig(cfg Viper) {
+// loadConfig attempts to load the configuration file and reports its status. It returns
+// an error if the file was found but cannot be decrypted.
+func loadConfig(cfg Viper) error {
 	// Try to read the configuration file.
 	err := cfg.ReadInConfig()
 	if err != nil {
@@ -172,7 +206,42 @@ func loadConfig(cfg Viper) {
 			fmt.Fprintf(os.Stderr, "Error reading config file: %v\n", err)
 		}
 	} else {
+		path := cfg.ConfigFileUsed()
+
+		// Replace the settings read from an encrypted file with the decrypted ones, as
+		// the commands cannot use an encrypted token.
+		err = decryptConfig(cfg, path)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration file %s: %w", path, err)
+		}
+
 		// Report the loaded configuration file path.
-		fmt.Fprintf(os.Stderr, "Using config file: %s\n", cfg.ConfigFileUsed())
+		fmt.Fprintf(os.Stderr, "Using config file: %s\n", path)
+	}
+
+	return nil
+}
+
+// decryptConfig reloads the configuration from the decrypted file at path, if the file
+// is a sops document or holds values encrypted with age.
+func decryptConfig(cfg Viper, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	if !IsEncrypted(data) {
+		return nil
 	}
+
+	plaintext, err := Decrypt(data)
+	if err != nil {
+		return err
+	}
+
+	return cfg.ReadConfig(bytes.NewReader(plaintext))
 }
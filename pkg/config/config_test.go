@@ -20,6 +20,7 @@ package config
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -46,7 +47,11 @@ func (*mockViper) SetEnvKeyReplacer(_ *strings.Replacer) {}
 func (*mockViper) AutomaticEnv()                         {}
 func (*mockViper) SetDefault(_ string, _ any)            {}
 func (*mockViper) ReadInConfig() error                   { return nil }
+func (*mockViper) ReadConfig(_ io.Reader) error          { return nil }
 func (*mockViper) ConfigFileUsed() string                { return "" }
+func (*mockViper) GetString(_ string) string             { return "" }
+func (*mockViper) GetStringMap(_ string) map[string]any  { return nil }
+func (*mockViper) MergeConfigMap(_ map[string]any) error { return nil }
 
 func TestInitConfig(t *testing.T) {
 	originalInitConfigFunc := InitConfigFunc
@@ -118,7 +123,12 @@ func TestInitConfig_NilFunc(t *testing.T) {
 func Test_initConfig(t *testing.T) {
 	m := mocks.NewMockViper(t)
 
-	cfgFileUsed := filepath.Join("test", "config.yaml")
+	cfgFileUsed := filepath.Join(t.TempDir(), "config.yaml")
+
+	err := os.WriteFile(cfgFileUsed, []byte("zone: example.com\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
 
 	m.EXPECT().ConfigFileUsed().Return(cfgFileUsed)
 	m.EXPECT().ReadInConfig().Return(nil)
@@ -302,6 +312,30 @@ func Test_setConfigFile(t *testing.T) {
 	}
 }
 
+func TestAppDir(t *testing.T) {
+	originalUserHomeDir := osUserHomeDir
+
+	defer func() { osUserHomeDir = originalUserHomeDir }()
+
+	osUserHomeDir = func() (string, error) { return filepath.Join("home", "test"), nil }
+
+	got, err := AppDir()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if want := filepath.Join("home", "test", AppDirName); got != want {
+		t.Errorf("AppDir() = %q, want %q", got, want)
+	}
+
+	osUserHomeDir = func() (string, error) { return "", errors.New("no home dir") }
+
+	_, err = AppDir()
+	if err == nil || !strings.Contains(err.Error(), "no home dir") {
+		t.Errorf("Expected error containing 'no home dir', got '%v'", err)
+	}
+}
+
 func Test_setEnv(t *testing.T) {
 	m := mocks.NewMockViper(t)
 
@@ -315,16 +349,26 @@ func Test_setEnv(t *testing.T) {
 }
 
 func Test_loadConfig(t *testing.T) {
+	recipients := useAgeKey(t)
+
+	encrypted, err := Encrypt([]byte("api_token: secret-token\n"), recipients, false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
 	tests := []struct {
 		name            string
 		readConfigError error
-		configFileUsed  string
+		content         string
+		keyFile         string
+		wantDecrypted   string
 		expectOutput    string
+		wantErr         error
 	}{
 		{
-			name:           "ConfigFound",
-			configFileUsed: filepath.Join("test", "config.yaml"),
-			expectOutput:   "Using config file: " + filepath.Join("test", "config.yaml") + "\n",
+			name:         "ConfigFound",
+			content:      "api_token: plain-token\n",
+			expectOutput: "Using config file: %s\n",
 		},
 		{
 			name:            "ConfigNotFound",
@@ -336,16 +380,50 @@ func Test_loadConfig(t *testing.T) {
 			readConfigError: errors.New("read error"),
 			expectOutput:    "Error reading config file: read error\n",
 		},
+		{
+			name:          "EncryptedConfig",
+			content:       string(encrypted),
+			wantDecrypted: "api_token: secret-token\n",
+			expectOutput:  "Using config file: %s\n",
+		},
+		{
+			name:    "EncryptedConfigWithoutKey",
+			content: string(encrypted),
+			keyFile: "missing.txt",
+			wantErr: ErrNoAgeIdentity,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.keyFile != "" {
+				t.Setenv(AgeKeyFileEnv, filepath.Join(t.TempDir(), tt.keyFile))
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
 			m := mocks.NewMockViper(t)
 
 			m.EXPECT().ReadInConfig().Return(tt.readConfigError).Once()
 
 			if tt.readConfigError == nil {
-				m.EXPECT().ConfigFileUsed().Return(tt.configFileUsed).Once()
+				m.EXPECT().ConfigFileUsed().Return(path).Once()
+			}
+
+			var decrypted string
+
+			if tt.wantDecrypted != "" {
+				m.EXPECT().ReadConfig(mock.Anything).RunAndReturn(func(in io.Reader) error {
+					data, err := io.ReadAll(in)
+					decrypted = string(data)
+
+					return err
+				}).Once()
 			}
 
 			var buf bytes.Buffer
@@ -361,7 +439,7 @@ func Test_loadConfig(t *testing.T) {
 
 			defer func() { os.Stderr = originalStderr }()
 
-			loadConfig(m)
+			loadErr := loadConfig(m)
 
 			_ = w.Close()
 
@@ -370,9 +448,21 @@ func Test_loadConfig(t *testing.T) {
 				t.Fatalf("Failed to copy stderr: %v", err)
 			}
 
-			output := buf.String()
-			if output != tt.expectOutput {
-				t.Errorf("Expected output '%q', got '%q'", tt.expectOutput, output)
+			expectOutput := tt.expectOutput
+			if strings.Contains(expectOutput, "%s") {
+				expectOutput = fmt.Sprintf(expectOutput, path)
+			}
+
+			if output := buf.String(); output != expectOutput {
+				t.Errorf("Expected output '%q', got '%q'", expectOutput, output)
+			}
+
+			if !errors.Is(loadErr, tt.wantErr) {
+				t.Errorf("loadConfig() error = %v, want %v", loadErr, tt.wantErr)
+			}
+
+			if decrypted != tt.wantDecrypted {
+				t.Errorf("ReadConfig() content = %q, want %q", decrypted, tt.wantDecrypted)
 			}
 		})
 	}
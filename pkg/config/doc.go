@@ -13,6 +13,16 @@
 //     underscores (e.g., api_token becomes CF_API_TOKEN).
 //  3. Loading the configuration file, reporting errors or success to stderr.
 //
+// Named profiles under the profiles key are merged over the top-level settings by
+// ApplyProfile, selected by the profile key (--profile or CF_PROFILE) or the
+// current_profile key. SetFileValue updates a single key of the configuration file
+// while preserving its comments.
+//
+// Configuration files encrypted in the sops format for age recipients, or holding
+// values encrypted with age, are decrypted when loaded with the identities in the
+// age key file (SOPS_AGE_KEY_FILE, or ~/.config/age/keys.txt). Encrypt and Decrypt
+// convert files between the two forms.
+//
 // The package defines a Viper interface to abstract configuration operations,
 // allowing for dependency injection during testing. Key functions include
 // InitConfig to start the configuration process, and internal helpers to set
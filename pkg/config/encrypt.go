@@ -0,0 +1,353 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"go.yaml.in/yaml/v3"
+)
+
+// Constants defining the age identities used to decrypt the configuration.
+const (
+	// AgeKeyFileEnv names the environment variable holding the path of the age identity file.
+	AgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+	// ageArmorHeader starts an ASCII-armored age ciphertext.
+	ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+)
+
+var (
+	// ErrNoAgeIdentity indicates that no age identity is available to decrypt the configuration.
+	ErrNoAgeIdentity = errors.New("no age identity found")
+
+	// ErrNoAgeRecipient indicates that the configuration was to be encrypted without any age recipient.
+	ErrNoAgeRecipient = errors.New("no age recipient given")
+
+	// ErrDecryptConfig indicates a failure to decrypt the configuration.
+	ErrDecryptConfig = errors.New("failed to decrypt configuration")
+
+	// ErrEncryptConfig indicates a failure to encrypt the configuration.
+	ErrEncryptConfig = errors.New("failed to encrypt configuration")
+
+	// ErrAlreadyEncrypted indicates a configuration that was to be encrypted twice.
+	ErrAlreadyEncrypted = errors.New("configuration is already encrypted")
+)
+
+// AgeKeyFile returns the path of the age identity file, given by SOPS_AGE_KEY_FILE or
+// defaulting to ~/.config/age/keys.txt.
+func AgeKeyFile() (string, error) {
+	if path := os.Getenv(AgeKeyFileEnv); path != "" {
+		return path, nil
+	}
+
+	homeDir, err := osUserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".config", "age", "keys.txt"), nil
+}
+
+// LoadAgeIdentities reads the age identities from the identity file.
+func LoadAgeIdentities() ([]age.Identity, error) {
+	path, err := AgeKeyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoAgeIdentity, err)
+	}
+
+	defer file.Close()
+
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s: %w", ErrNoAgeIdentity, path, err)
+	}
+
+	return identities, nil
+}
+
+// IdentityRecipients returns the recipients, i.e. the public keys, of age identities.
+func IdentityRecipients(identities []age.Identity) []string {
+	recipients := make([]string, 0, len(identities))
+
+	for _, identity := range identities {
+		switch identity := identity.(type) {
+		case *age.X25519Identity:
+			recipients = append(recipients, identity.Recipient().String())
+		case *age.HybridIdentity:
+			recipients = append(recipients, identity.Recipient().String())
+		}
+	}
+
+	return recipients
+}
+
+// IsEncrypted reports whether configuration data is a sops document or holds values
+// encrypted with age.
+func IsEncrypted(data []byte) bool {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil || doc.Kind != yaml.DocumentNode {
+		return false
+	}
+
+	return sopsMetadataNode(doc.Content[0]) != nil || hasAgeValues(&doc)
+}
+
+// Decrypt returns configuration data with the sops encryption removed and the values
+// encrypted with age decrypted, keeping comments. The age identities are only loaded if
+// the data is encrypted.
+func Decrypt(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	identities, err := LoadAgeIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	err = decryptDocument(&doc, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeDocument(&doc)
+}
+
+// Encrypt encrypts configuration data for the given age recipients. The whole document
+// is encrypted with sops, or, with tokenOnly, only the api_token values are encrypted
+// with age, leaving the other settings readable.
+func Encrypt(data []byte, recipients []string, tokenOnly bool) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, ErrNoAgeRecipient
+	}
+
+	if IsEncrypted(data) {
+		return nil, ErrAlreadyEncrypted
+	}
+
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, ErrInvalidConfigFile
+	}
+
+	if tokenOnly {
+		err = encryptTokens(doc.Content[0], recipients)
+	} else {
+		err = encryptSOPS(&doc, newSOPSMetadata(recipients))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeDocument(&doc)
+}
+
+// decryptDocument decrypts a parsed configuration document in place.
+func decryptDocument(doc *yaml.Node, identities []age.Identity) error {
+	if doc.Kind != yaml.DocumentNode {
+		return nil
+	}
+
+	if sopsMetadataNode(doc.Content[0]) != nil {
+		err := decryptSOPS(doc, identities)
+		if err != nil {
+			return err
+		}
+	}
+
+	return walkScalars(doc, nil, func(node *yaml.Node, _ []string) error {
+		if !isAgeEncrypted(node.Value) {
+			return nil
+		}
+
+		plaintext, err := decryptAge(node.Value, identities)
+		if err != nil {
+			return err
+		}
+
+		node.Value = string(plaintext)
+		node.Tag = "!!str"
+		node.Style = 0
+
+		return nil
+	})
+}
+
+// encryptTokens encrypts the non-empty api_token values of a configuration, including
+// those of profiles, with age.
+func encryptTokens(root *yaml.Node, recipients []string) error {
+	return walkScalars(root, nil, func(node *yaml.Node, path []string) error {
+		if path[len(path)-1] != APITokenKey || node.Value == "" {
+			return nil
+		}
+
+		ciphertext, err := encryptAge([]byte(node.Value), recipients)
+		if err != nil {
+			return err
+		}
+
+		node.Value = ciphertext
+		node.Tag = "!!str"
+		node.Style = yaml.LiteralStyle
+
+		return nil
+	})
+}
+
+// hasAgeValues reports whether any value of a document is encrypted with age.
+func hasAgeValues(doc *yaml.Node) bool {
+	errFound := errors.New("found")
+
+	err := walkScalars(doc, nil, func(node *yaml.Node, _ []string) error {
+		if isAgeEncrypted(node.Value) {
+			return errFound
+		}
+
+		return nil
+	})
+
+	return errors.Is(err, errFound)
+}
+
+// isAgeEncrypted reports whether a value is an ASCII-armored age ciphertext.
+func isAgeEncrypted(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), ageArmorHeader)
+}
+
+// decryptAge decrypts an ASCII-armored age ciphertext.
+func decryptAge(ciphertext string, identities []age.Identity) ([]byte, error) {
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(strings.TrimSpace(ciphertext))), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptConfig, err)
+	}
+
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptConfig, err)
+	}
+
+	return plaintext, nil
+}
+
+// encryptAge encrypts plaintext for the given recipients, returning it ASCII-armored.
+func encryptAge(plaintext []byte, recipients []string) (string, error) {
+	parsed, err := age.ParseRecipients(strings.NewReader(strings.Join(recipients, "\n")))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	var buf bytes.Buffer
+
+	armorWriter := armor.NewWriter(&buf)
+
+	writer, err := age.Encrypt(armorWriter, parsed...)
+	if err == nil {
+		_, err = writer.Write(plaintext)
+	}
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err == nil {
+		err = armorWriter.Close()
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	return buf.String(), nil
+}
+
+// walkScalars calls fn for every scalar value of a YAML node, in document order, with the
+// path of mapping keys leading to it. Sequence items share the path of their sequence.
+func walkScalars(node *yaml.Node, path []string, fn func(node *yaml.Node, path []string) error) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			err := walkScalars(child, path, fn)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := append(path[:len(path):len(path)], node.Content[i].Value)
+
+			err := walkScalars(node.Content[i+1], childPath, fn)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if len(path) > 0 {
+			return fn(node, path)
+		}
+	}
+
+	return nil
+}
+
+// encodeDocument encodes a YAML document with the conventional indentation.
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	err := encoder.Encode(doc)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
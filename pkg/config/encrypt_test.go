@@ -0,0 +1,323 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"go.yaml.in/yaml/v3"
+)
+
+// useAgeKey writes a new age identity to a key file named by SOPS_AGE_KEY_FILE and
+// returns its recipient.
+func useAgeKey(t *testing.T) []string {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.txt")
+
+	err = os.WriteFile(path, []byte("# test key\n"+identity.String()+"\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write age key file: %v", err)
+	}
+
+	t.Setenv(AgeKeyFileEnv, path)
+
+	return []string{identity.Recipient().String()}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	const plaintext = `# Master token
+api_token: secret-token
+zone: example.com
+dry_run: true
+account_unencrypted: Personal
+allow_ip:
+  - 192.0.2.0/24
+profiles:
+  staging:
+    api_token: staging-token # staging only
+    zone: ""
+`
+
+	tests := []struct {
+		name          string
+		tokenOnly     bool
+		wantHidden    []string
+		wantReadable  []string
+		wantSOPSValue bool
+	}{
+		{
+			name:          "SOPS",
+			wantHidden:    []string{"secret-token", "staging-token", "example.com", "192.0.2.0/24", "true", "Master token", "staging only"},
+			wantReadable:  []string{"account_unencrypted: Personal", "zone: \"\""},
+			wantSOPSValue: true,
+		},
+		{
+			name:         "TokenOnly",
+			tokenOnly:    true,
+			wantHidden:   []string{"secret-token", "staging-token"},
+			wantReadable: []string{"zone: example.com", "dry_run: true", "# staging only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients := useAgeKey(t)
+
+			encrypted, err := Encrypt([]byte(plaintext), recipients, tt.tokenOnly)
+			if err != nil {
+				t.Fatalf("Encrypt() error = %v", err)
+			}
+
+			for _, hidden := range tt.wantHidden {
+				if strings.Contains(string(encrypted), hidden) {
+					t.Errorf("Encrypt() output contains %q:\n%s", hidden, encrypted)
+				}
+			}
+
+			for _, readable := range tt.wantReadable {
+				if !strings.Contains(string(encrypted), readable) {
+					t.Errorf("Encrypt() output does not contain %q:\n%s", readable, encrypted)
+				}
+			}
+
+			if got := SOPSRecipients(encrypted) != nil; got != tt.wantSOPSValue {
+				t.Errorf("SOPSRecipients() != nil = %v, want %v", got, tt.wantSOPSValue)
+			}
+
+			if !IsEncrypted(encrypted) {
+				t.Error("IsEncrypted() = false, want true")
+			}
+
+			_, err = Encrypt(encrypted, recipients, tt.tokenOnly)
+			if !errors.Is(err, ErrAlreadyEncrypted) {
+				t.Errorf("Encrypt() of encrypted data error = %v, want %v", err, ErrAlreadyEncrypted)
+			}
+
+			decrypted, err := Decrypt(encrypted)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+
+			if string(decrypted) != plaintext {
+				t.Errorf("Decrypt() = %q, want %q", decrypted, plaintext)
+			}
+		})
+	}
+}
+
+func TestEncrypt_Errors(t *testing.T) {
+	_, err := Encrypt([]byte("api_token: token\n"), nil, false)
+	if !errors.Is(err, ErrNoAgeRecipient) {
+		t.Errorf("Encrypt() without recipients error = %v, want %v", err, ErrNoAgeRecipient)
+	}
+
+	_, err = Encrypt([]byte("api_token: token\n"), []string{"not-a-recipient"}, false)
+	if !errors.Is(err, ErrEncryptConfig) {
+		t.Errorf("Encrypt() with invalid recipient error = %v, want %v", err, ErrEncryptConfig)
+	}
+
+	_, err = Encrypt([]byte("- item\n"), []string{"age1"}, false)
+	if !errors.Is(err, ErrInvalidConfigFile) {
+		t.Errorf("Encrypt() of a list error = %v, want %v", err, ErrInvalidConfigFile)
+	}
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	recipients := useAgeKey(t)
+
+	encrypted, err := Encrypt([]byte("api_token: secret-token\nzone: example.com\n"), recipients, false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// Swap the two encrypted values, which are individually valid.
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(encrypted, &doc)
+	if err != nil {
+		t.Fatalf("Failed to parse encrypted config: %v", err)
+	}
+
+	root := doc.Content[0]
+	root.Content[1].Value, root.Content[3].Value = root.Content[3].Value, root.Content[1].Value
+
+	swapped, err := encodeDocument(&doc)
+	if err != nil {
+		t.Fatalf("Failed to encode config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		keyFile string
+		wantErr error
+	}{
+		{
+			name:    "SwappedValues",
+			data:    string(swapped),
+			wantErr: ErrDecryptConfig,
+		},
+		{
+			name:    "WrongIdentity",
+			data:    string(encrypted),
+			keyFile: "other",
+			wantErr: ErrDecryptConfig,
+		},
+		{
+			name:    "MissingIdentity",
+			data:    string(encrypted),
+			keyFile: "missing",
+			wantErr: ErrNoAgeIdentity,
+		},
+		{
+			name:    "CommentRegex",
+			data:    "api_token: ENC[AES256_GCM,data:YQ==,iv:YQ==,tag:YQ==,type:str]\nsops:\n  age:\n    - recipient: age1\n      enc: key\n  unencrypted_comment_regex: public\n  mac: ENC[AES256_GCM,data:YQ==,iv:YQ==,tag:YQ==,type:str]\n",
+			wantErr: ErrUnsupportedSOPS,
+		},
+		{
+			name:    "NotAgeEncrypted",
+			data:    "api_token: ENC[AES256_GCM,data:YQ==,iv:YQ==,tag:YQ==,type:str]\nsops:\n  kms:\n    - arn: arn:aws:kms:example\n  mac: ENC[AES256_GCM,data:YQ==,iv:YQ==,tag:YQ==,type:str]\n",
+			wantErr: ErrUnsupportedSOPS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			switch tt.keyFile {
+			case "other":
+				useAgeKey(t)
+			case "missing":
+				t.Setenv(AgeKeyFileEnv, filepath.Join(t.TempDir(), "missing.txt"))
+			}
+
+			_, err := Decrypt([]byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecrypt_Plaintext(t *testing.T) {
+	// Plaintext is returned as is, without requiring an age identity.
+	t.Setenv(AgeKeyFileEnv, filepath.Join(t.TempDir(), "missing.txt"))
+
+	data := []byte("# comment\napi_token:   token\n")
+
+	got, err := Decrypt(data)
+	if err != nil || string(got) != string(data) {
+		t.Errorf("Decrypt() = %q, %v, want %q", got, err, data)
+	}
+}
+
+func TestDecrypt_SOPSFixtures(t *testing.T) {
+	// The fixtures were encrypted by sops 3.13.3 and by Encrypt, and all of them decrypt
+	// with sops, for the identity in testdata/sops/keys.txt.
+	t.Setenv(AgeKeyFileEnv, filepath.Join("testdata", "sops", "keys.txt"))
+
+	plaintext, err := os.ReadFile(filepath.Join("testdata", "sops", "plain.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read plaintext fixture: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "SOPS", file: "encrypted_by_sops.yaml"},
+		{name: "SOPSMACOnlyEncrypted", file: "encrypted_by_sops_mac_only.yaml"},
+		{name: "Package", file: "encrypted_by_package.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "sops", tt.file))
+			if err != nil {
+				t.Fatalf("Failed to read fixture: %v", err)
+			}
+
+			decrypted, err := Decrypt(data)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+
+			if string(decrypted) != string(plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", decrypted, plaintext)
+			}
+		})
+	}
+}
+
+func TestEncrypt_DecryptedBySOPS(t *testing.T) {
+	sops, err := exec.LookPath("sops")
+	if err != nil {
+		t.Skip("sops is not installed")
+	}
+
+	keyFile := filepath.Join("testdata", "sops", "keys.txt")
+	t.Setenv(AgeKeyFileEnv, keyFile)
+
+	identities, err := LoadAgeIdentities()
+	if err != nil {
+		t.Fatalf("LoadAgeIdentities() error = %v", err)
+	}
+
+	plaintext, err := os.ReadFile(filepath.Join("testdata", "sops", "plain.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read plaintext fixture: %v", err)
+	}
+
+	encrypted, err := Encrypt(plaintext, IdentityRecipients(identities), false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err = os.WriteFile(path, encrypted, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write encrypted config: %v", err)
+	}
+
+	// Compare with what sops makes of its own encryption of the same plaintext.
+	got, err := exec.Command(sops, "--decrypt", path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("sops --decrypt error = %v: %s", err, got)
+	}
+
+	want, err := exec.Command(sops, "--decrypt", filepath.Join("testdata", "sops", "encrypted_by_sops.yaml")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("sops --decrypt error = %v: %s", err, want)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("sops --decrypt = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/atomicfile"
+)
+
+// yamlIndent is the indentation used when rewriting the configuration file.
+const yamlIndent = 2
+
+var (
+	// ErrNoConfigFile indicates that a setting was to be saved, but no configuration file is in use.
+	ErrNoConfigFile = errors.New("no configuration file in use")
+
+	// ErrInvalidConfigFile indicates a configuration file whose top level is not a map of settings.
+	ErrInvalidConfigFile = errors.New("configuration file must contain a map of settings")
+)
+
+// SetFileValue sets a top-level key of the YAML configuration file at path to value,
+// preserving the rest of the file, including its comments. Only the file is changed;
+// settings from flags or environment variables are never written to it. A sops document
+// is decrypted and encrypted again with the same encryption rules and keys.
+func SetFileValue(path, key, value string) error {
+	if path == "" {
+		return ErrNoConfigFile
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	// Parse the file into a node tree, which keeps comments and key order.
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	// Treat an empty file as an empty map.
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: %s", ErrInvalidConfigFile, path)
+	}
+
+	// The values of a sops document are authenticated together, so that a single value
+	// cannot be changed without encrypting the document again.
+	encrypted := sopsMetadataNode(root) != nil
+
+	var metadata sopsMetadata
+
+	if encrypted {
+		metadata, err = editSOPSMetadata(root)
+		if err != nil {
+			return err
+		}
+
+		identities, err := LoadAgeIdentities()
+		if err != nil {
+			return err
+		}
+
+		err = decryptSOPS(&doc, identities)
+		if err != nil {
+			return err
+		}
+	}
+
+	setMappingValue(root, key, value)
+
+	if encrypted {
+		err = encryptSOPS(&doc, metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Encode the updated file with the conventional indentation.
+	updated, err := encodeDocument(&doc)
+	if err != nil {
+		return err
+	}
+
+	// Replace the file in one step, so that it is never left half-written.
+	err = atomicfile.Write(path, updated, info.Mode().Perm(), true)
+	if err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	return nil
+}
+
+// setMappingValue sets key to a string value in a YAML mapping node, appending the key
+// if it is not present yet.
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+
+			return
+		}
+	}
+
+	mapping.Content = append(
+		mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestSetFileValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ReplaceValue",
+			content: `# Master token
+api_token: "token" # keep this comment
+current_profile: staging
+profiles:
+    staging:
+        zone: staging.example.com
+`,
+			want: `# Master token
+api_token: "token" # keep this comment
+current_profile: production
+profiles:
+  staging:
+    zone: staging.example.com
+`,
+		},
+		{
+			name:    "AppendValue",
+			content: "api_token: token\n",
+			want:    "api_token: token\ncurrent_profile: production\n",
+		},
+		{
+			name:    "EmptyFile",
+			content: "",
+			want:    "current_profile: production\n",
+		},
+		{
+			name:    "NotAMap",
+			content: "- item\n",
+			want:    "- item\n",
+			wantErr: ErrInvalidConfigFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			err = SetFileValue(path, CurrentProfileKey, "production")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetFileValue() error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("config file = %q, want %q", got, tt.want)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Failed to stat config: %v", err)
+			}
+
+			if mode := info.Mode().Perm(); mode != 0o600 {
+				t.Errorf("config file mode = %o, want 600", mode)
+			}
+		})
+	}
+}
+
+func TestSetFileValue_NoConfigFile(t *testing.T) {
+	err := SetFileValue("", CurrentProfileKey, "production")
+	if !errors.Is(err, ErrNoConfigFile) {
+		t.Errorf("SetFileValue() error = %v, want %v", err, ErrNoConfigFile)
+	}
+}
+
+func TestSetFileValue_SOPS(t *testing.T) {
+	recipients := useAgeKey(t)
+
+	encrypted, err := Encrypt([]byte("api_token: token\ncurrent_profile: staging\n"), recipients, false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err = os.WriteFile(path, encrypted, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err = SetFileValue(path, CurrentProfileKey, "production")
+	if err != nil {
+		t.Fatalf("SetFileValue() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	if !slices.Equal(SOPSRecipients(got), recipients) {
+		t.Errorf("SOPSRecipients() = %q, want %q", SOPSRecipients(got), recipients)
+	}
+
+	decrypted, err := Decrypt(got)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	want := "api_token: token\ncurrent_profile: production\n"
+	if string(decrypted) != want {
+		t.Errorf("decrypted config file = %q, want %q", decrypted, want)
+	}
+}
+
+func TestSetFileValue_SOPSEncryptionRules(t *testing.T) {
+	// The fixture was encrypted by sops with encrypted_regex and mac_only_encrypted, which
+	// must be kept when the document is encrypted again.
+	keyFile, err := filepath.Abs(filepath.Join("testdata", "sops", "keys.txt"))
+	if err != nil {
+		t.Fatalf("Failed to resolve age key file: %v", err)
+	}
+
+	t.Setenv(AgeKeyFileEnv, keyFile)
+
+	data, err := os.ReadFile(filepath.Join("testdata", "sops", "encrypted_by_sops_mac_only.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	plaintext, err := os.ReadFile(filepath.Join("testdata", "sops", "plain.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read plaintext fixture: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err = SetFileValue(path, CurrentProfileKey, "production")
+	if err != nil {
+		t.Fatalf("SetFileValue() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(got, &doc)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	metadata, err := decodeSOPSMetadata(doc.Content[0])
+	if err != nil {
+		t.Fatalf("decodeSOPSMetadata() error = %v", err)
+	}
+
+	if metadata.EncryptedRegex != "^(api_token|zone)$" || metadata.UnencryptedSuffix != "" ||
+		!metadata.MACOnlyEncrypted {
+		t.Errorf("sops metadata = %+v, want the encryption rules of the fixture", metadata)
+	}
+
+	for _, line := range []string{"dry_run: true", "current_profile: production", "api_token: ENC["} {
+		if !strings.Contains(string(got), line) {
+			t.Errorf("config file = %q, want it to contain %q", got, line)
+		}
+	}
+
+	decrypted, err := Decrypt(got)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	want := string(plaintext) + "current_profile: production\n"
+	if string(decrypted) != want {
+		t.Errorf("decrypted config file = %q, want %q", decrypted, want)
+	}
+
+	sops, err := exec.LookPath("sops")
+	if err != nil {
+		return
+	}
+
+	out, err := exec.Command(sops, "--decrypt", path).CombinedOutput()
+	if err != nil {
+		t.Errorf("sops --decrypt error = %v: %s", err, out)
+	}
+}
+
+func TestSetFileValue_SOPSOtherKeys(t *testing.T) {
+	data := []byte(`api_token: ENC[AES256_GCM,data:AAAA,iv:AAAA,tag:AAAA,type:str]
+sops:
+  pgp:
+    - fp: 0123456789ABCDEF
+      enc: key
+  lastmodified: "2026-01-01T00:00:00Z"
+  mac: ENC[AES256_GCM,data:AAAA,iv:AAAA,tag:AAAA,type:str]
+  version: 3.9.4
+`)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err := os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err = SetFileValue(path, CurrentProfileKey, "production")
+	if !errors.Is(err, ErrUnsupportedSOPS) {
+		t.Errorf("SetFileValue() error = %v, want %v", err, ErrUnsupportedSOPS)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != string(data) {
+		t.Errorf("config file = %q, %v, want it unchanged", got, err)
+	}
+}
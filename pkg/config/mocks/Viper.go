@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"io"
 	"strings"
 
 	mock "github.com/stretchr/testify/mock"
@@ -154,6 +155,212 @@ func (_c *MockViper_ConfigFileUsed_Call) RunAndReturn(run func() string) *MockVi
 	return _c
 }
 
+// GetString provides a mock function for the type MockViper
+func (_mock *MockViper) GetString(key string) string {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetString")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockViper_GetString_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetString'
+type MockViper_GetString_Call struct {
+	*mock.Call
+}
+
+// GetString is a helper method to define mock.On call
+//   - key string
+func (_e *MockViper_Expecter) GetString(key interface{}) *MockViper_GetString_Call {
+	return &MockViper_GetString_Call{Call: _e.mock.On("GetString", key)}
+}
+
+func (_c *MockViper_GetString_Call) Run(run func(key string)) *MockViper_GetString_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockViper_GetString_Call) Return(s string) *MockViper_GetString_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockViper_GetString_Call) RunAndReturn(run func(key string) string) *MockViper_GetString_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStringMap provides a mock function for the type MockViper
+func (_mock *MockViper) GetStringMap(key string) map[string]any {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStringMap")
+	}
+
+	var r0 map[string]any
+	if returnFunc, ok := ret.Get(0).(func(string) map[string]any); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]any)
+		}
+	}
+	return r0
+}
+
+// MockViper_GetStringMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStringMap'
+type MockViper_GetStringMap_Call struct {
+	*mock.Call
+}
+
+// GetStringMap is a helper method to define mock.On call
+//   - key string
+func (_e *MockViper_Expecter) GetStringMap(key interface{}) *MockViper_GetStringMap_Call {
+	return &MockViper_GetStringMap_Call{Call: _e.mock.On("GetStringMap", key)}
+}
+
+func (_c *MockViper_GetStringMap_Call) Run(run func(key string)) *MockViper_GetStringMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockViper_GetStringMap_Call) Return(m map[string]any) *MockViper_GetStringMap_Call {
+	_c.Call.Return(m)
+	return _c
+}
+
+func (_c *MockViper_GetStringMap_Call) RunAndReturn(run func(key string) map[string]any) *MockViper_GetStringMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeConfigMap provides a mock function for the type MockViper
+func (_mock *MockViper) MergeConfigMap(cfg map[string]any) error {
+	ret := _mock.Called(cfg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeConfigMap")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(map[string]any) error); ok {
+		r0 = returnFunc(cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockViper_MergeConfigMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeConfigMap'
+type MockViper_MergeConfigMap_Call struct {
+	*mock.Call
+}
+
+// MergeConfigMap is a helper method to define mock.On call
+//   - cfg map[string]any
+func (_e *MockViper_Expecter) MergeConfigMap(cfg interface{}) *MockViper_MergeConfigMap_Call {
+	return &MockViper_MergeConfigMap_Call{Call: _e.mock.On("MergeConfigMap", cfg)}
+}
+
+func (_c *MockViper_MergeConfigMap_Call) Run(run func(cfg map[string]any)) *MockViper_MergeConfigMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 map[string]any
+		if args[0] != nil {
+			arg0 = args[0].(map[string]any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockViper_MergeConfigMap_Call) Return(err error) *MockViper_MergeConfigMap_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockViper_MergeConfigMap_Call) RunAndReturn(run func(cfg map[string]any) error) *MockViper_MergeConfigMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadConfig provides a mock function for the type MockViper
+func (_mock *MockViper) ReadConfig(in io.Reader) error {
+	ret := _mock.Called(in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadConfig")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader) error); ok {
+		r0 = returnFunc(in)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockViper_ReadConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadConfig'
+type MockViper_ReadConfig_Call struct {
+	*mock.Call
+}
+
+// ReadConfig is a helper method to define mock.On call
+//   - in io.Reader
+func (_e *MockViper_Expecter) ReadConfig(in interface{}) *MockViper_ReadConfig_Call {
+	return &MockViper_ReadConfig_Call{Call: _e.mock.On("ReadConfig", in)}
+}
+
+func (_c *MockViper_ReadConfig_Call) Run(run func(in io.Reader)) *MockViper_ReadConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockViper_ReadConfig_Call) Return(err error) *MockViper_ReadConfig_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockViper_ReadConfig_Call) RunAndReturn(run func(in io.Reader) error) *MockViper_ReadConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadInConfig provides a mock function for the type MockViper
 func (_mock *MockViper) ReadInConfig() error {
 	ret := _mock.Called()
@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Constants defining the configuration keys used to select profiles.
+const (
+	// ProfileKey selects a profile for a single run, set via --profile or CF_PROFILE.
+	ProfileKey = "profile"
+	// CurrentProfileKey is the configuration file key holding the default profile.
+	CurrentProfileKey = "current_profile"
+	// ProfilesKey is the configuration file key holding the map of named profiles.
+	ProfilesKey = "profiles"
+)
+
+var (
+	// ErrUnknownProfile indicates that no profile exists with the selected name.
+	ErrUnknownProfile = errors.New("unknown profile")
+
+	// ErrInvalidProfile indicates a profile that is not a map of settings.
+	ErrInvalidProfile = errors.New("profile must be a map of settings")
+)
+
+// ActiveProfile returns the name of the selected profile: the profile key (from
+// --profile or CF_PROFILE) if set, otherwise the current_profile key. It returns an
+// empty string if no profile is selected. Profile names are case-insensitive.
+func ActiveProfile(cfg Viper) string {
+	name := cfg.GetString(ProfileKey)
+	if name == "" {
+		name = cfg.GetString(CurrentProfileKey)
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ProfileNames returns the sorted names of the configured profiles.
+func ProfileNames(cfg Viper) []string {
+	return slices.Sorted(maps.Keys(cfg.GetStringMap(ProfilesKey)))
+}
+
+// Profile returns the settings of the named profile.
+func Profile(cfg Viper, name string) (map[string]any, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	value, ok := cfg.GetStringMap(ProfilesKey)[name]
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w %q (configured: %s)",
+			ErrUnknownProfile,
+			name,
+			strings.Join(ProfileNames(cfg), ", "),
+		)
+	}
+
+	// An empty profile is valid and inherits every setting.
+	if value == nil {
+		return map[string]any{}, nil
+	}
+
+	settings, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProfile, name)
+	}
+
+	return settings, nil
+}
+
+// ApplyProfile merges the settings of the active profile over the top-level settings of
+// the configuration file, so that flags and environment variables still take precedence.
+// It returns the name of the applied profile, or an empty string if none is selected.
+func ApplyProfile(cfg Viper) (string, error) {
+	name := ActiveProfile(cfg)
+	if name == "" {
+		return "", nil
+	}
+
+	settings, err := Profile(cfg, name)
+	if err != nil {
+		return "", err
+	}
+
+	// Copy the settings, so that the profile itself is left unchanged.
+	merged := maps.Clone(settings)
+
+	// A zones list in the profile replaces a single top-level zone, which would otherwise
+	// take precedence over it.
+	if _, ok := merged["zones"]; ok {
+		if _, ok := merged["zone"]; !ok {
+			merged["zone"] = ""
+		}
+	}
+
+	err = cfg.MergeConfigMap(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+
+	return name, nil
+}
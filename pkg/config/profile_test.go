@@ -0,0 +1,168 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/config/mocks"
+)
+
+const profileConfig = `
+api_token: shared-token
+zone: example.com
+account: Personal
+current_profile: staging
+profiles:
+  staging:
+    api_token: staging-token
+    zone: staging.example.com
+  Production:
+    api_token: production-token
+    account: Production
+    zones:
+      - example.com
+      - example.org
+  empty:
+  invalid: not-a-map
+`
+
+// newProfileViper returns a Viper instance that has read profileConfig.
+func newProfileViper(t *testing.T) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType(ConfigExt)
+
+	err := v.ReadConfig(strings.NewReader(profileConfig))
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	return v
+}
+
+func TestApplyProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		wantProfile string
+		wantToken   string
+		wantZone    string
+		wantZones   []string
+		wantAccount string
+		wantErr     error
+	}{
+		{
+			name:        "CurrentProfile",
+			wantProfile: "staging",
+			wantToken:   "staging-token",
+			wantZone:    "staging.example.com",
+			wantAccount: "Personal",
+		},
+		{
+			name:        "SelectedProfile",
+			profile:     "PRODUCTION",
+			wantProfile: "production",
+			wantToken:   "production-token",
+			wantZones:   []string{"example.com", "example.org"},
+			wantAccount: "Production",
+		},
+		{
+			name:        "EmptyProfile",
+			profile:     "empty",
+			wantProfile: "empty",
+			wantToken:   "shared-token",
+			wantZone:    "example.com",
+			wantAccount: "Personal",
+		},
+		{
+			name:    "UnknownProfile",
+			profile: "missing",
+			wantErr: ErrUnknownProfile,
+		},
+		{
+			name:    "InvalidProfile",
+			profile: "invalid",
+			wantErr: ErrInvalidProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newProfileViper(t)
+			if tt.profile != "" {
+				v.Set(ProfileKey, tt.profile)
+			}
+
+			got, err := ApplyProfile(v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyProfile() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got != tt.wantProfile {
+				t.Errorf("ApplyProfile() = %q, want %q", got, tt.wantProfile)
+			}
+
+			if token := v.GetString("api_token"); token != tt.wantToken {
+				t.Errorf("api_token = %q, want %q", token, tt.wantToken)
+			}
+
+			if zone := v.GetString("zone"); zone != tt.wantZone {
+				t.Errorf("zone = %q, want %q", zone, tt.wantZone)
+			}
+
+			if zones := v.GetStringSlice("zones"); !slices.Equal(zones, tt.wantZones) {
+				t.Errorf("zones = %v, want %v", zones, tt.wantZones)
+			}
+
+			if account := v.GetString("account"); account != tt.wantAccount {
+				t.Errorf("account = %q, want %q", account, tt.wantAccount)
+			}
+		})
+	}
+}
+
+func TestApplyProfile_NoProfile(t *testing.T) {
+	m := mocks.NewMockViper(t)
+	m.EXPECT().GetString(ProfileKey).Return("")
+	m.EXPECT().GetString(CurrentProfileKey).Return("")
+
+	got, err := ApplyProfile(m)
+	if err != nil || got != "" {
+		t.Errorf("ApplyProfile() = %q, %v, want no profile", got, err)
+	}
+}
+
+func TestProfileNames(t *testing.T) {
+	got := ProfileNames(newProfileViper(t))
+	want := []string{"empty", "invalid", "production", "staging"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("ProfileNames() = %v, want %v", got, want)
+	}
+}
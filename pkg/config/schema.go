@@ -0,0 +1,323 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ValueType describes the type of value a setting accepts.
+type ValueType string
+
+// Constants defining the types of value settings accept.
+const (
+	// TypeString accepts a scalar, such as a string or a number.
+	TypeString ValueType = "string"
+	// TypeStrings accepts a scalar or a list of scalars.
+	TypeStrings ValueType = "string or list of strings"
+	// TypeBool accepts true or false.
+	TypeBool ValueType = "boolean"
+	// TypePresets accepts a map of preset names to lists of permission groups.
+	TypePresets ValueType = "map of string lists"
+	// TypeProfiles accepts a map of profile names to maps of settings.
+	TypeProfiles ValueType = "map of profiles"
+)
+
+// insecurePermissions are the permission bits that give other users access to a file.
+const insecurePermissions = 0o077
+
+// Setting describes a configuration key and the type of value it accepts.
+type Setting struct {
+	// Key is the configuration key, also settable through the CF_ environment variable
+	// of the same name in upper case.
+	Key string
+	// Type is the type of value the key accepts.
+	Type ValueType
+}
+
+// Settings lists the supported configuration keys, in the order they are shown.
+var Settings = []Setting{
+	{Key: APITokenKey, Type: TypeString},
+	{Key: APITokenFileKey, Type: TypeString},
+	{Key: APITokenCommandKey, Type: TypeString},
+	{Key: APIKeyKey, Type: TypeString},
+	{Key: APIEmailKey, Type: TypeString},
+	{Key: "zone", Type: TypeStrings},
+	{Key: "zones", Type: TypeStrings},
+	{Key: "zone_id", Type: TypeStrings},
+	{Key: "account", Type: TypeString},
+	{Key: "owner", Type: TypeString},
+	{Key: ProfileKey, Type: TypeString},
+	{Key: CurrentProfileKey, Type: TypeString},
+	{Key: ProfilesKey, Type: TypeProfiles},
+	{Key: "name_template", Type: TypeString},
+	{Key: "expires_in", Type: TypeString},
+	{Key: "expires_on", Type: TypeString},
+	{Key: "not_before", Type: TypeString},
+	{Key: "preset", Type: TypeString},
+	{Key: "presets", Type: TypePresets},
+	{Key: "permissions", Type: TypeStrings},
+	{Key: "allow_ip", Type: TypeStrings},
+	{Key: "deny_ip", Type: TypeStrings},
+	{Key: "output", Type: TypeString},
+	{Key: "format", Type: TypeString},
+	{Key: "out", Type: TypeString},
+	{Key: "force", Type: TypeBool},
+	{Key: "secret_name", Type: TypeString},
+	{Key: "secret_namespace", Type: TypeString},
+	{Key: "secret_key", Type: TypeString},
+	{Key: "dry_run", Type: TypeBool},
+	{Key: "offline", Type: TypeBool},
+	{Key: "replace", Type: TypeBool},
+	{Key: "allow_duplicate", Type: TypeBool},
+}
+
+// profileExcludedKeys lists the keys that select profiles, which have no effect inside one.
+var profileExcludedKeys = []string{ProfileKey, CurrentProfileKey, ProfilesKey}
+
+// Problem describes an issue found in the configuration file.
+type Problem struct {
+	// Key is the dotted path of the offending key, or empty for problems with the file itself.
+	Key string
+	// Message describes the problem.
+	Message string
+}
+
+// String returns the problem as "key: message", or only the message if no key is set.
+func (p Problem) String() string {
+	if p.Key == "" {
+		return p.Message
+	}
+
+	return p.Key + ": " + p.Message
+}
+
+// LookupSetting returns the setting of a configuration key, ignoring case.
+func LookupSetting(key string) (Setting, bool) {
+	key = strings.ToLower(key)
+
+	index := slices.IndexFunc(Settings, func(s Setting) bool { return s.Key == key })
+	if index < 0 {
+		return Setting{}, false
+	}
+
+	return Settings[index], true
+}
+
+// ValidateFile checks the configuration file at path against the supported settings.
+// It reports unknown keys, values of the wrong type, an unknown current_profile, and
+// file permissions that give other users access to the secrets in the file. Encrypted
+// files are decrypted first. An error is only returned if the file cannot be read.
+func ValidateFile(path string) ([]Problem, error) {
+	if path == "" {
+		return nil, ErrNoConfigFile
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var problems []Problem
+
+	// File modes are not meaningful on Windows.
+	if mode := info.Mode().Perm(); runtime.GOOS != "windows" && mode&insecurePermissions != 0 {
+		problems = append(problems, Problem{
+			Message: fmt.Sprintf("file permissions %04o allow access by other users (use chmod 600)", mode),
+		})
+	}
+
+	// Validate the decrypted settings of an encrypted file.
+	data, err = Decrypt(data)
+	if err != nil {
+		return append(problems, Problem{Message: err.Error()}), nil
+	}
+
+	var settings map[string]any
+
+	err = yaml.Unmarshal(data, &settings)
+	if err != nil {
+		return append(problems, Problem{Message: fmt.Sprintf("invalid YAML: %v", err)}), nil
+	}
+
+	problems = append(problems, validateSettings("", settings, nil)...)
+
+	// Check that the default profile exists.
+	current, _ := lookupValue(settings, CurrentProfileKey).(string)
+	if current != "" {
+		profiles, _ := lookupValue(settings, ProfilesKey).(map[string]any)
+		if !hasKey(profiles, current) {
+			problems = append(problems, Problem{
+				Key:     CurrentProfileKey,
+				Message: fmt.Sprintf("%s %q", ErrUnknownProfile, current),
+			})
+		}
+	}
+
+	return problems, nil
+}
+
+// validateSettings checks a map of settings, prefixing reported keys with prefix.
+// Keys listed in excluded are reported as unknown.
+func validateSettings(prefix string, settings map[string]any, excluded []string) []Problem {
+	var problems []Problem
+
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		path := prefix + key
+
+		setting, ok := LookupSetting(key)
+		if !ok || slices.Contains(excluded, setting.Key) {
+			problems = append(problems, Problem{Key: path, Message: "unknown key"})
+
+			continue
+		}
+
+		value := settings[key]
+		if value == nil {
+			continue
+		}
+
+		if !matchesType(setting.Type, value) {
+			problems = append(problems, Problem{
+				Key:     path,
+				Message: fmt.Sprintf("expected %s, got %s", setting.Type, describeValue(value)),
+			})
+
+			continue
+		}
+
+		// Check the settings of each profile.
+		if setting.Type == TypeProfiles {
+			profiles, _ := value.(map[string]any)
+
+			for _, name := range slices.Sorted(maps.Keys(profiles)) {
+				profile, _ := profiles[name].(map[string]any)
+				problems = append(problems, validateSettings(path+"."+name+".", profile, profileExcludedKeys)...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// matchesType reports whether value is of the given type.
+func matchesType(typ ValueType, value any) bool {
+	switch typ {
+	case TypeString:
+		return isScalar(value)
+	case TypeStrings:
+		return isScalar(value) || isScalarList(value)
+	case TypeBool:
+		_, ok := value.(bool)
+
+		return ok
+	case TypePresets:
+		presets, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		for _, groups := range presets {
+			if !isScalar(groups) && !isScalarList(groups) {
+				return false
+			}
+		}
+
+		return true
+	case TypeProfiles:
+		profiles, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		for _, profile := range profiles {
+			if _, ok := profile.(map[string]any); !ok && profile != nil {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// isScalar reports whether value is a string or a number.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, int, int64, uint64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isScalarList reports whether value is a list of strings or numbers.
+func isScalarList(value any) bool {
+	list, ok := value.([]any)
+
+	return ok && !slices.ContainsFunc(list, func(item any) bool { return !isScalar(item) })
+}
+
+// describeValue names the type of a decoded YAML value for error messages.
+func describeValue(value any) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	default:
+		if isScalar(value) {
+			return "string"
+		}
+
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// lookupValue returns the value of a key in settings, ignoring case.
+func lookupValue(settings map[string]any, key string) any {
+	for name, value := range settings {
+		if strings.EqualFold(name, key) {
+			return value
+		}
+	}
+
+	return nil
+}
+
+// hasKey reports whether settings contains a key, ignoring case.
+func hasKey(settings map[string]any, key string) bool {
+	return slices.ContainsFunc(slices.Collect(maps.Keys(settings)), func(name string) bool {
+		return strings.EqualFold(name, key)
+	})
+}
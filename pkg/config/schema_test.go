@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		mode    os.FileMode
+		want    []string
+	}{
+		{
+			name: "Valid",
+			content: `api_token: token
+zone: example.com
+zone_id: [023e105f4ecef8ad9ca31a8372d0c353]
+expires_in: 90d
+dry_run: true
+presets:
+  ci-purge: [Zone Read, Cache Purge]
+current_profile: Staging
+profiles:
+  staging:
+    zones: [example.com, example.org]
+  empty:
+`,
+		},
+		{
+			name:    "EmptyFile",
+			content: "",
+		},
+		{
+			name:    "UnknownKeys",
+			content: "api_token: token\nzome: example.com\nprofiles:\n  staging:\n    current_profile: other\n",
+			want: []string{
+				"profiles.staging.current_profile: unknown key",
+				"zome: unknown key",
+			},
+		},
+		{
+			name:    "TypeErrors",
+			content: "api_token: [a, b]\ndry_run: \"yes\"\npresets:\n  ci: {a: b}\nprofiles:\n  staging: example.com\n",
+			want: []string{
+				"api_token: expected string, got list",
+				"dry_run: expected boolean, got string",
+				"presets: expected map of string lists, got map",
+				"profiles: expected map of profiles, got map",
+			},
+		},
+		{
+			name:    "UnknownCurrentProfile",
+			content: "current_profile: deleted\nprofiles:\n  staging: {}\n",
+			want:    []string{`current_profile: unknown profile "deleted"`},
+		},
+		{
+			name:    "InsecurePermissions",
+			content: "api_token: token\n",
+			mode:    0o644,
+			want:    []string{"file permissions 0644 allow access by other users (use chmod 600)"},
+		},
+		{
+			name:    "InvalidYAML",
+			content: "- not\n- a map\n",
+			want:    []string{"invalid YAML: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into map[string]interface {}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mode != 0 && runtime.GOOS == "windows" {
+				t.Skip("file permissions are not checked on Windows")
+			}
+
+			mode := tt.mode
+			if mode == 0 {
+				mode = 0o600
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(tt.content), mode)
+			if err == nil {
+				err = os.Chmod(path, mode)
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			problems, err := ValidateFile(path)
+			if err != nil {
+				t.Fatalf("ValidateFile() error = %v", err)
+			}
+
+			got := make([]string, 0, len(problems))
+			for _, problem := range problems {
+				got = append(got, problem.String())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFile_Errors(t *testing.T) {
+	_, err := ValidateFile("")
+	if !errors.Is(err, ErrNoConfigFile) {
+		t.Errorf("ValidateFile(\"\") error = %v, want %v", err, ErrNoConfigFile)
+	}
+
+	_, err = ValidateFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ValidateFile(missing) error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestValidateFile_Encrypted(t *testing.T) {
+	recipients := useAgeKey(t)
+
+	encrypted, err := Encrypt([]byte("api_token: token\ndry_run: true\nzome: example.com\n"), recipients, false)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err = os.WriteFile(path, encrypted, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+
+	// The sops metadata is not reported, and the values are checked after decryption.
+	if len(problems) != 1 || problems[0].String() != "zome: unknown key" {
+		t.Errorf("ValidateFile() = %v, want [zome: unknown key]", problems)
+	}
+
+	t.Setenv(AgeKeyFileEnv, filepath.Join(t.TempDir(), "missing.txt"))
+
+	problems, err = ValidateFile(path)
+	if err != nil || len(problems) != 1 || !strings.HasPrefix(problems[0].String(), ErrNoAgeIdentity.Error()) {
+		t.Errorf("ValidateFile() without identity = %v, %v, want a %q problem", problems, err, ErrNoAgeIdentity)
+	}
+}
@@ -0,0 +1,782 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"go.yaml.in/yaml/v3"
+)
+
+// Constants defining the sops document format.
+const (
+	// sopsKey is the top-level key holding the sops metadata.
+	sopsKey = "sops"
+	// sopsVersion is the sops version recorded in encrypted documents.
+	sopsVersion = "3.9.4"
+	// sopsUnencryptedSuffix marks keys whose values are left unencrypted.
+	sopsUnencryptedSuffix = "_unencrypted"
+	// sopsDataKeyLength is the length of the AES-256 data key.
+	sopsDataKeyLength = 32
+	// sopsNonceLength is the length of the AES-GCM nonce used by sops.
+	sopsNonceLength = 32
+	// sopsCommentType is the sops type of an encrypted comment.
+	sopsCommentType = "comment"
+)
+
+// ErrUnsupportedSOPS indicates a sops document that uses features other than age keys.
+var ErrUnsupportedSOPS = errors.New("unsupported sops document")
+
+// sopsOtherKeyTypes are the metadata keys listing keys of other types than age.
+var sopsOtherKeyTypes = []string{"kms", "gcp_kms", "azure_kv", "hc_vault", "pgp"}
+
+// sopsValuePattern matches a value encrypted by sops.
+var sopsValuePattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.+),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// sopsMACOnlyEncryptedInitialization is hashed first into the message authentication code
+// of documents with mac_only_encrypted set, so that it differs from one without it.
+var sopsMACOnlyEncryptedInitialization = []byte{
+	0x8a, 0x3f, 0xd2, 0xad, 0x54, 0xce, 0x66, 0x52, 0x7b, 0x10, 0x34, 0xf3, 0xd1, 0x47, 0xbe, 0x0b,
+	0x0b, 0x97, 0x5b, 0x3b, 0xf4, 0x4f, 0x72, 0xc6, 0xfd, 0xad, 0xec, 0x81, 0x76, 0xf2, 0x7d, 0x69,
+}
+
+// sopsMetadata is the metadata stored under the sops key. Only age keys are supported;
+// documents encrypted for other key types must be decrypted with sops itself.
+type sopsMetadata struct {
+	KeyGroups         []sopsKeyGroup `yaml:"key_groups,omitempty"`
+	ShamirThreshold   int            `yaml:"shamir_threshold,omitempty"`
+	AgeKeys           []sopsAgeKey   `yaml:"age,omitempty"`
+	LastModified      string         `yaml:"lastmodified"`
+	MAC               string         `yaml:"mac"`
+	UnencryptedSuffix string         `yaml:"unencrypted_suffix,omitempty"`
+	EncryptedSuffix   string         `yaml:"encrypted_suffix,omitempty"`
+	UnencryptedRegex  string         `yaml:"unencrypted_regex,omitempty"`
+	EncryptedRegex    string         `yaml:"encrypted_regex,omitempty"`
+	MACOnlyEncrypted  bool           `yaml:"mac_only_encrypted,omitempty"`
+	Version           string         `yaml:"version"`
+
+	UnencryptedCommentRegex string `yaml:"unencrypted_comment_regex,omitempty"`
+	EncryptedCommentRegex   string `yaml:"encrypted_comment_regex,omitempty"`
+}
+
+// sopsKeyGroup is a group of keys, any of which can decrypt the data key.
+type sopsKeyGroup struct {
+	AgeKeys []sopsAgeKey `yaml:"age,omitempty"`
+}
+
+// sopsAgeKey is the data key encrypted for an age recipient.
+type sopsAgeKey struct {
+	Recipient    string `yaml:"recipient"`
+	EncryptedKey string `yaml:"enc"`
+}
+
+// sopsMetadataNode returns the value of the sops key of a mapping node, or nil if there is none.
+func sopsMetadataNode(root *yaml.Node) *yaml.Node {
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == sopsKey && root.Content[i+1].Kind == yaml.MappingNode {
+			return root.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// decodeSOPSMetadata decodes the sops metadata of a document.
+func decodeSOPSMetadata(root *yaml.Node) (sopsMetadata, error) {
+	var metadata sopsMetadata
+
+	node := sopsMetadataNode(root)
+	if node == nil {
+		return metadata, fmt.Errorf("%w: no %s metadata", ErrUnsupportedSOPS, sopsKey)
+	}
+
+	err := node.Decode(&metadata)
+	if err != nil {
+		return metadata, fmt.Errorf("%w: %w", ErrUnsupportedSOPS, err)
+	}
+
+	if metadata.ShamirThreshold > 1 {
+		return metadata, fmt.Errorf("%w: shamir_threshold is not supported", ErrUnsupportedSOPS)
+	}
+
+	if metadata.UnencryptedCommentRegex != "" || metadata.EncryptedCommentRegex != "" {
+		return metadata, fmt.Errorf("%w: comment regexes are not supported", ErrUnsupportedSOPS)
+	}
+
+	return metadata, nil
+}
+
+// newSOPSMetadata returns the metadata of a new sops document for the given age
+// recipients, which leaves the values of keys with the unencrypted suffix unencrypted.
+func newSOPSMetadata(recipients []string) sopsMetadata {
+	metadata := sopsMetadata{UnencryptedSuffix: sopsUnencryptedSuffix}
+	for _, recipient := range recipients {
+		metadata.AgeKeys = append(metadata.AgeKeys, sopsAgeKey{Recipient: recipient})
+	}
+
+	return metadata
+}
+
+// editSOPSMetadata returns the metadata of a sops document for encrypting it again after
+// it was edited, with the same encryption rules and keys. Keys of other types than age
+// cannot be encrypted for, so documents using them are refused rather than losing them.
+func editSOPSMetadata(root *yaml.Node) (sopsMetadata, error) {
+	metadata, err := decodeSOPSMetadata(root)
+	if err != nil {
+		return metadata, err
+	}
+
+	if hasOtherSOPSKeys(sopsMetadataNode(root)) {
+		return metadata, fmt.Errorf(
+			"%w: only documents encrypted solely with age keys can be edited",
+			ErrUnsupportedSOPS,
+		)
+	}
+
+	if len(metadata.ageKeys()) == 0 {
+		return metadata, fmt.Errorf("%w: not encrypted with age", ErrUnsupportedSOPS)
+	}
+
+	return metadata, nil
+}
+
+// hasOtherSOPSKeys reports whether sops metadata, or one of its key groups, lists keys of
+// other types than age.
+func hasOtherSOPSKeys(node *yaml.Node) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+
+		switch {
+		case slices.Contains(sopsOtherKeyTypes, key) && len(value.Content) > 0:
+			return true
+		case key == "key_groups":
+			for _, group := range value.Content {
+				if hasOtherSOPSKeys(group) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// ageKeys returns the age keys of the metadata, including those of key groups.
+func (m sopsMetadata) ageKeys() []sopsAgeKey {
+	keys := m.AgeKeys
+	for _, group := range m.KeyGroups {
+		keys = append(keys, group.AgeKeys...)
+	}
+
+	return keys
+}
+
+// encrypts reports whether sops encrypts the value at path.
+func (m sopsMetadata) encrypts(path []string) bool {
+	anyKey := func(match func(key string) bool) bool {
+		for _, key := range path {
+			if match(key) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch {
+	case m.UnencryptedSuffix != "":
+		return !anyKey(func(key string) bool { return strings.HasSuffix(key, m.UnencryptedSuffix) })
+	case m.EncryptedSuffix != "":
+		return anyKey(func(key string) bool { return strings.HasSuffix(key, m.EncryptedSuffix) })
+	case m.UnencryptedRegex != "":
+		pattern, err := regexp.Compile(m.UnencryptedRegex)
+
+		return err != nil || !anyKey(pattern.MatchString)
+	case m.EncryptedRegex != "":
+		pattern, err := regexp.Compile(m.EncryptedRegex)
+
+		return err == nil && anyKey(pattern.MatchString)
+	}
+
+	return true
+}
+
+// SOPSRecipients returns the age recipients a sops document is encrypted for, or nil if
+// data is not a sops document.
+func SOPSRecipients(data []byte) []string {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil || doc.Kind != yaml.DocumentNode {
+		return nil
+	}
+
+	metadata, err := decodeSOPSMetadata(doc.Content[0])
+	if err != nil {
+		return nil
+	}
+
+	recipients := make([]string, 0, len(metadata.ageKeys()))
+	for _, key := range metadata.ageKeys() {
+		recipients = append(recipients, key.Recipient)
+	}
+
+	return recipients
+}
+
+// decryptSOPS decrypts the values and comments of a sops document in place, verifies its
+// message authentication code, and removes the sops metadata.
+func decryptSOPS(doc *yaml.Node, identities []age.Identity) error {
+	root := doc.Content[0]
+
+	metadata, err := decodeSOPSMetadata(root)
+	if err != nil {
+		return err
+	}
+
+	dataKey, err := sopsDataKey(metadata, identities)
+	if err != nil {
+		return err
+	}
+
+	removeKey(root, sopsKey)
+
+	err = liftSOPSComments(root, nil, metadata, dataKey)
+	if err != nil {
+		return err
+	}
+
+	hash := sha512.New()
+	if metadata.MACOnlyEncrypted {
+		hash.Write(sopsMACOnlyEncryptedInitialization)
+	}
+
+	err = walkScalars(root, nil, func(node *yaml.Node, path []string) error {
+		encrypted := sopsValuePattern.MatchString(node.Value) && metadata.encrypts(path)
+		if encrypted {
+			err := decryptSOPSValue(node, dataKey, sopsAdditionalData(path))
+			if err != nil {
+				return err
+			}
+		}
+
+		if encrypted || !metadata.MACOnlyEncrypted {
+			hash.Write(sopsMACBytes(node))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Check that no values were changed, removed, or reordered.
+	mac := &yaml.Node{Kind: yaml.ScalarNode, Value: metadata.MAC}
+
+	err = decryptSOPSValue(mac, dataKey, sopsMACAdditionalData(metadata.LastModified))
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(mac.Value, fmt.Sprintf("%X", hash.Sum(nil))) {
+		return fmt.Errorf("%w: message authentication code mismatch", ErrDecryptConfig)
+	}
+
+	// Comments are not covered by the message authentication code.
+	return walkComments(doc, nil, func(comment *string, path []string) error {
+		if !metadata.encrypts(path) {
+			return nil
+		}
+
+		return mapCommentLines(comment, func(line string) (string, error) {
+			if !sopsValuePattern.MatchString(line) {
+				return line, nil
+			}
+
+			plaintext, _, err := openSOPS(line, dataKey, sopsAdditionalData(path))
+
+			return plaintext, err
+		})
+	})
+}
+
+// encryptSOPS encrypts the values and comments of a configuration document in place as a
+// sops document, following the encryption rules of metadata and encrypting a new data key
+// for its age keys and key groups.
+func encryptSOPS(doc *yaml.Node, metadata sopsMetadata) error {
+	metadata.LastModified = time.Now().UTC().Format(time.RFC3339)
+	metadata.Version = sopsVersion
+
+	dataKey := make([]byte, sopsDataKeyLength)
+
+	_, err := rand.Read(dataKey)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	metadata.AgeKeys, err = encryptSOPSDataKey(metadata.AgeKeys, dataKey)
+	if err != nil {
+		return err
+	}
+
+	groups := make([]sopsKeyGroup, 0, len(metadata.KeyGroups))
+	for _, group := range metadata.KeyGroups {
+		group.AgeKeys, err = encryptSOPSDataKey(group.AgeKeys, dataKey)
+		if err != nil {
+			return err
+		}
+
+		groups = append(groups, group)
+	}
+
+	if len(groups) > 0 {
+		metadata.KeyGroups = groups
+	}
+
+	hash := sha512.New()
+	if metadata.MACOnlyEncrypted {
+		hash.Write(sopsMACOnlyEncryptedInitialization)
+	}
+
+	err = walkScalars(doc, nil, func(node *yaml.Node, path []string) error {
+		if !metadata.MACOnlyEncrypted || metadata.encrypts(path) {
+			hash.Write(sopsMACBytes(node))
+		}
+
+		if !metadata.encrypts(path) || node.Tag == "!!null" || node.Value == "" {
+			return nil
+		}
+
+		return encryptSOPSValue(node, dataKey, sopsAdditionalData(path))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = walkComments(doc, nil, func(comment *string, path []string) error {
+		if !metadata.encrypts(path) {
+			return nil
+		}
+
+		return mapCommentLines(comment, func(line string) (string, error) {
+			if line == "" {
+				return line, nil
+			}
+
+			return sealSOPS(line, sopsCommentType, dataKey, sopsAdditionalData(path))
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	mac := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprintf("%X", hash.Sum(nil))}
+
+	err = encryptSOPSValue(mac, dataKey, sopsMACAdditionalData(metadata.LastModified))
+	if err != nil {
+		return err
+	}
+
+	metadata.MAC = mac.Value
+
+	var node yaml.Node
+
+	err = node.Encode(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	root := doc.Content[0]
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: sopsKey}, &node)
+
+	return nil
+}
+
+// encryptSOPSDataKey returns a copy of age keys with the data key encrypted for each of
+// their recipients.
+func encryptSOPSDataKey(keys []sopsAgeKey, dataKey []byte) ([]sopsAgeKey, error) {
+	encrypted := make([]sopsAgeKey, 0, len(keys))
+
+	for _, key := range keys {
+		encryptedKey, err := encryptAge(dataKey, []string{key.Recipient})
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = append(encrypted, sopsAgeKey{Recipient: key.Recipient, EncryptedKey: encryptedKey})
+	}
+
+	return encrypted, nil
+}
+
+// sopsDataKey decrypts the data key of a sops document with the first matching age identity.
+func sopsDataKey(metadata sopsMetadata, identities []age.Identity) ([]byte, error) {
+	keys := metadata.ageKeys()
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: not encrypted with age", ErrUnsupportedSOPS)
+	}
+
+	var errs []error
+
+	for _, key := range keys {
+		dataKey, err := decryptAge(key.EncryptedKey, identities)
+		if err == nil {
+			return dataKey, nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// sopsAdditionalData returns the additional authenticated data binding a value to its path.
+func sopsAdditionalData(path []string) string {
+	return strings.Join(path, ":") + ":"
+}
+
+// sopsMACAdditionalData returns the additional authenticated data of the message
+// authentication code, the modification time in RFC 3339 format.
+func sopsMACAdditionalData(lastModified string) string {
+	parsed, err := time.Parse(time.RFC3339, lastModified)
+	if err != nil {
+		return lastModified
+	}
+
+	return parsed.UTC().Format(time.RFC3339)
+}
+
+// sopsPlaintext returns the plaintext sops encrypts for a scalar node, and its sops type.
+// Numbers are normalized and booleans capitalized, as sops does.
+func sopsPlaintext(node *yaml.Node) (string, string) {
+	switch node.Tag {
+	case "!!int":
+		if value, err := strconv.ParseInt(node.Value, 0, 64); err == nil {
+			return strconv.FormatInt(value, 10), "int"
+		}
+	case "!!float":
+		if value, err := strconv.ParseFloat(node.Value, 64); err == nil {
+			return strconv.FormatFloat(value, 'f', -1, 64), "float"
+		}
+	case "!!bool":
+		var value bool
+		if node.Decode(&value) == nil {
+			if value {
+				return "True", "bool"
+			}
+
+			return "False", "bool"
+		}
+	case "!!timestamp":
+		var value time.Time
+		if node.Decode(&value) == nil {
+			if text, err := value.MarshalText(); err == nil {
+				return string(text), "time"
+			}
+		}
+	case "!!null":
+		return "", "null"
+	}
+
+	return node.Value, "str"
+}
+
+// sopsMACBytes returns the bytes of a plaintext scalar node hashed into the message
+// authentication code.
+func sopsMACBytes(node *yaml.Node) []byte {
+	plaintext, _ := sopsPlaintext(node)
+
+	return []byte(plaintext)
+}
+
+// newSOPSCipher returns the AES-GCM cipher sops encrypts values with.
+func newSOPSCipher(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCMWithNonceSize(block, sopsNonceLength)
+}
+
+// encryptSOPSValue encrypts a scalar node in place.
+func encryptSOPSValue(node *yaml.Node, dataKey []byte, additionalData string) error {
+	plaintext, valueType := sopsPlaintext(node)
+
+	value, err := sealSOPS(plaintext, valueType, dataKey, additionalData)
+	if err != nil {
+		return err
+	}
+
+	node.Value = value
+	node.Tag = "!!str"
+	node.Style = 0
+
+	return nil
+}
+
+// decryptSOPSValue decrypts a scalar node in place, restoring its type.
+func decryptSOPSValue(node *yaml.Node, dataKey []byte, additionalData string) error {
+	plaintext, valueType, err := openSOPS(node.Value, dataKey, additionalData)
+	if err != nil {
+		return err
+	}
+
+	node.Value = plaintext
+	node.Style = 0
+
+	switch valueType {
+	case "int":
+		node.Tag = "!!int"
+	case "float":
+		node.Tag = "!!float"
+	case "bool":
+		node.Tag = "!!bool"
+		node.Value = strings.ToLower(node.Value)
+	case "time":
+		node.Tag = "!!timestamp"
+	default:
+		node.Tag = "!!str"
+	}
+
+	return nil
+}
+
+// sealSOPS encrypts plaintext of the given sops type, returning it in the sops format.
+func sealSOPS(plaintext, valueType string, dataKey []byte, additionalData string) (string, error) {
+	gcm, err := newSOPSCipher(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	nonce := make([]byte, sopsNonceLength)
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncryptConfig, err)
+	}
+
+	sealed := gcm.Seal(nil, nonce, []byte(plaintext), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf(
+		"ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(nonce),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType,
+	), nil
+}
+
+// openSOPS decrypts a value in the sops format, returning its plaintext and sops type.
+func openSOPS(value string, dataKey []byte, additionalData string) (string, string, error) {
+	match := sopsValuePattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", "", fmt.Errorf("%w: invalid encrypted value", ErrDecryptConfig)
+	}
+
+	var parts [3][]byte
+
+	for i := range parts {
+		decoded, err := base64.StdEncoding.DecodeString(match[i+1])
+		if err != nil {
+			return "", "", fmt.Errorf("%w: invalid encrypted value: %w", ErrDecryptConfig, err)
+		}
+
+		parts[i] = decoded
+	}
+
+	data, nonce, tag := parts[0], parts[1], parts[2]
+
+	gcm, err := newSOPSCipher(dataKey)
+	if err != nil || len(nonce) != sopsNonceLength {
+		return "", "", fmt.Errorf("%w: invalid encrypted value", ErrDecryptConfig)
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: value at %q: %w", ErrDecryptConfig, strings.TrimSuffix(additionalData, ":"), err)
+	}
+
+	return string(plaintext), match[4], nil
+}
+
+// walkComments calls fn for every comment of a YAML node with the path sops binds it to:
+// that of the node if it is a mapping or sequence, and otherwise that of its parent.
+func walkComments(node *yaml.Node, path []string, fn func(comment *string, path []string) error) error {
+	for _, comment := range []*string{&node.HeadComment, &node.LineComment, &node.FootComment} {
+		if *comment == "" {
+			continue
+		}
+
+		err := fn(comment, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			err := walkComments(child, path, fn)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			valuePath := path
+			if value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode {
+				valuePath = append(path[:len(path):len(path)], key.Value)
+			}
+
+			err := walkComments(key, path, fn)
+			if err == nil {
+				err = walkComments(value, valuePath, fn)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// liftSOPSComments decrypts the comments sops stores as items of sequences, which have no
+// other place for them, and moves them back into the comments of the items that follow.
+func liftSOPSComments(node *yaml.Node, path []string, metadata sopsMetadata, dataKey []byte) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := append(path[:len(path):len(path)], node.Content[i].Value)
+
+			err := liftSOPSComments(node.Content[i+1], childPath, metadata, dataKey)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		items := node.Content[:0]
+
+		var comments []string
+
+		for _, item := range node.Content {
+			match := sopsValuePattern.FindStringSubmatch(item.Value)
+			if item.Kind == yaml.ScalarNode && match != nil && match[4] == sopsCommentType && metadata.encrypts(path) {
+				comment, _, err := openSOPS(item.Value, dataKey, sopsAdditionalData(path))
+				if err != nil {
+					return err
+				}
+
+				comments = append(comments, "#"+comment)
+
+				continue
+			}
+
+			err := liftSOPSComments(item, path, metadata, dataKey)
+			if err != nil {
+				return err
+			}
+
+			item.HeadComment = joinComments(append(comments, item.HeadComment)...)
+			comments = nil
+			items = append(items, item)
+		}
+
+		node.Content = items
+
+		if len(comments) > 0 {
+			last := node
+			if len(items) > 0 {
+				last = items[len(items)-1]
+			}
+
+			last.FootComment = joinComments(append([]string{last.FootComment}, comments...)...)
+		}
+	}
+
+	return nil
+}
+
+// joinComments joins the non-empty comments given, one per line.
+func joinComments(comments ...string) string {
+	lines := make([]string, 0, len(comments))
+
+	for _, comment := range comments {
+		if comment != "" {
+			lines = append(lines, comment)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// mapCommentLines replaces each line of a comment with the result of fn, which is given
+// the text of the line after its '#'.
+func mapCommentLines(comment *string, fn func(line string) (string, error)) error {
+	lines := strings.Split(*comment, "\n")
+
+	for i, line := range lines {
+		text, ok := strings.CutPrefix(line, "#")
+		if !ok {
+			continue
+		}
+
+		text, err := fn(text)
+		if err != nil {
+			return err
+		}
+
+		lines[i] = "#" + text
+	}
+
+	*comment = strings.Join(lines, "\n")
+
+	return nil
+}
+
+// removeKey removes a key and its value from a mapping node.
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Constants defining the configuration keys holding the master API token or its source.
+const (
+	// APITokenKey holds the master API token itself.
+	APITokenKey = "api_token"
+	// APITokenFileKey holds the path of a file containing the master API token.
+	APITokenFileKey = "api_token_file"
+	// APITokenCommandKey holds a shell command that prints the master API token.
+	APITokenCommandKey = "api_token_command"
+	// APIKeyKey holds the legacy Global API Key, used if no master API token is set.
+	APIKeyKey = "api_key"
+	// APIEmailKey holds the email address of the user owning the Global API Key.
+	APIEmailKey = "api_email"
+)
+
+// tokenSourceKeys lists the keys that are alternative sources of the master API token.
+var tokenSourceKeys = []string{APITokenKey, APITokenFileKey, APITokenCommandKey}
+
+var (
+	// ErrConflictingTokenSources indicates that the master API token was given in more than one way.
+	ErrConflictingTokenSources = errors.New("only one of api_token, api_token_file, and api_token_command may be set")
+
+	// ErrReadTokenFile indicates a failure to read the file named by api_token_file.
+	ErrReadTokenFile = errors.New("failed to read api_token_file")
+
+	// ErrTokenCommandFailed indicates that the command named by api_token_command failed.
+	ErrTokenCommandFailed = errors.New("api_token_command failed")
+
+	// ErrEmptyToken indicates a master API token source that yielded an empty token.
+	ErrEmptyToken = errors.New("master API token is empty")
+)
+
+// execCommand creates the command run for api_token_command, defaulting to exec.CommandContext.
+var execCommand = exec.CommandContext
+
+// ResolveAPIToken returns the master API token from the api_token setting, the file named
+// by api_token_file, or the output of api_token_command. Trailing newlines are stripped
+// from files and command output. It returns an empty token, and no error, if none of the
+// settings is set.
+//
+// Sources set by environment variables replace those of the active profile, which in
+// turn replace the top-level sources of the configuration file. Only sources set in the
+// same place conflict.
+func ResolveAPIToken(ctx context.Context, cfg Viper) (string, error) {
+	get := tokenSourceLayer(cfg)
+
+	token := get(APITokenKey)
+	file := get(APITokenFileKey)
+	command := get(APITokenCommandKey)
+
+	// Refuse ambiguous settings rather than silently preferring one source.
+	sources := 0
+
+	for _, value := range []string{token, file, command} {
+		if value != "" {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return "", ErrConflictingTokenSources
+	}
+
+	switch {
+	case file != "":
+		return readTokenFile(file)
+	case command != "":
+		return runTokenCommand(ctx, command)
+	default:
+		return token, nil
+	}
+}
+
+// tokenSourceLayer returns a function reading the master token sources from the place
+// with the highest precedence that sets any of them: the environment, the active profile,
+// or the top level of the configuration file.
+func tokenSourceLayer(cfg Viper) func(key string) string {
+	if slices.ContainsFunc(tokenSourceKeys, func(key string) bool { return envSetting(key) != "" }) {
+		return envSetting
+	}
+
+	// The profile has been merged into the configuration by ApplyProfile, so its values
+	// are read from cfg, ignoring the top-level sources it does not set.
+	if name := ActiveProfile(cfg); name != "" {
+		settings, err := Profile(cfg, name)
+		if err == nil && slices.ContainsFunc(tokenSourceKeys, func(key string) bool {
+			_, ok := settings[key]
+
+			return ok
+		}) {
+			return func(key string) string {
+				if _, ok := settings[key]; !ok {
+					return ""
+				}
+
+				return cfg.GetString(key)
+			}
+		}
+	}
+
+	return cfg.GetString
+}
+
+// envSetting returns the value of the environment variable setting the configuration key.
+func envSetting(key string) string {
+	return os.Getenv("CF_" + strings.ToUpper(key))
+}
+
+// readTokenFile reads the master API token from a file, such as a mounted Docker secret.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrReadTokenFile, err)
+	}
+
+	token := TrimToken(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%w: %s contains no token", ErrEmptyToken, path)
+	}
+
+	return token, nil
+}
+
+// runTokenCommand runs a shell command and returns its output as the master API token.
+// The command inherits stdin and stderr, so that helpers can prompt for a passphrase.
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	shell, flag := "/bin/sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	var stdout bytes.Buffer
+
+	cmd := execCommand(ctx, shell, flag, command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrTokenCommandFailed, command, err)
+	}
+
+	token := TrimToken(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("%w: %q printed no token", ErrEmptyToken, command)
+	}
+
+	return token, nil
+}
+
+// TrimToken strips the trailing line breaks that files, commands, and piped input
+// usually end with.
+func TrimToken(value string) string {
+	return strings.TrimRight(value, "\r\n")
+}
@@ -0,0 +1,217 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestHelperProcess is not a real test. It stands in for the shell run by
+// api_token_command, behaving according to the command it is given.
+func TestHelperProcess(_ *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	switch command := os.Args[len(os.Args)-1]; command {
+	case "print-token":
+		fmt.Fprint(os.Stdout, "command-token\r\n")
+	case "print-nothing":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", command)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func TestResolveAPIToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+
+	err := os.WriteFile(tokenFile, []byte("file-token\n\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+
+	err = os.WriteFile(emptyFile, []byte("\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		// profile holds the settings of the active profile, if any.
+		profile map[string]any
+		// env holds the environment variables to set.
+		env     map[string]string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "NoSource",
+		},
+		{
+			name:     "Token",
+			settings: map[string]string{APITokenKey: "plain-token"},
+			want:     "plain-token",
+		},
+		{
+			name:     "File",
+			settings: map[string]string{APITokenFileKey: tokenFile},
+			want:     "file-token",
+		},
+		{
+			name:     "MissingFile",
+			settings: map[string]string{APITokenFileKey: filepath.Join(dir, "missing")},
+			wantErr:  ErrReadTokenFile,
+		},
+		{
+			name:     "EmptyFile",
+			settings: map[string]string{APITokenFileKey: emptyFile},
+			wantErr:  ErrEmptyToken,
+		},
+		{
+			name:     "Command",
+			settings: map[string]string{APITokenCommandKey: "print-token"},
+			want:     "command-token",
+		},
+		{
+			name:     "CommandFails",
+			settings: map[string]string{APITokenCommandKey: "exit-1"},
+			wantErr:  ErrTokenCommandFailed,
+		},
+		{
+			name:     "CommandPrintsNothing",
+			settings: map[string]string{APITokenCommandKey: "print-nothing"},
+			wantErr:  ErrEmptyToken,
+		},
+		{
+			name:     "ConflictingSources",
+			settings: map[string]string{APITokenKey: "plain-token", APITokenFileKey: tokenFile},
+			wantErr:  ErrConflictingTokenSources,
+		},
+		{
+			name:     "EnvTokenReplacesFile",
+			settings: map[string]string{APITokenFileKey: tokenFile},
+			env:      map[string]string{"CF_API_TOKEN": "env-token"},
+			want:     "env-token",
+		},
+		{
+			name:     "EnvFileReplacesToken",
+			settings: map[string]string{APITokenKey: "plain-token"},
+			env:      map[string]string{"CF_API_TOKEN_FILE": tokenFile},
+			want:     "file-token",
+		},
+		{
+			name:    "ConflictingEnvSources",
+			env:     map[string]string{"CF_API_TOKEN": "env-token", "CF_API_TOKEN_COMMAND": "print-token"},
+			wantErr: ErrConflictingTokenSources,
+		},
+		{
+			name:     "ProfileFileReplacesToken",
+			settings: map[string]string{APITokenKey: "plain-token"},
+			profile:  map[string]any{APITokenFileKey: tokenFile},
+			want:     "file-token",
+		},
+		{
+			name:     "ProfileWithoutSource",
+			settings: map[string]string{APITokenKey: "plain-token"},
+			profile:  map[string]any{"zone": "staging.example.com"},
+			want:     "plain-token",
+		},
+		{
+			name:     "EnvReplacesProfile",
+			settings: map[string]string{APITokenKey: "plain-token"},
+			profile:  map[string]any{APITokenFileKey: tokenFile},
+			env:      map[string]string{"CF_API_TOKEN": "env-token"},
+			want:     "env-token",
+		},
+		{
+			name:    "ConflictingProfileSources",
+			profile: map[string]any{APITokenKey: "plain-token", APITokenCommandKey: "print-token"},
+			wantErr: ErrConflictingTokenSources,
+		},
+	}
+
+	originalExecCommand := execCommand
+
+	defer func() { execCommand = originalExecCommand }()
+
+	// Run this test binary as the shell, so that the tests do not depend on one.
+	execCommand = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=TestHelperProcess", "--"}, args...)...)
+		cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+
+		return cmd
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range tokenSourceKeys {
+				t.Setenv("CF_"+strings.ToUpper(key), tt.env["CF_"+strings.ToUpper(key)])
+			}
+
+			// Load the settings as the configuration file would, so that the profile
+			// is merged over them.
+			fileSettings := map[string]any{}
+			for key, value := range tt.settings {
+				fileSettings[key] = value
+			}
+
+			if tt.profile != nil {
+				fileSettings[CurrentProfileKey] = "staging"
+				fileSettings[ProfilesKey] = map[string]any{"staging": tt.profile}
+			}
+
+			v := viper.New()
+
+			err := v.MergeConfigMap(fileSettings)
+			if err != nil {
+				t.Fatalf("Failed to load settings: %v", err)
+			}
+
+			_, err = ApplyProfile(v)
+			if err != nil {
+				t.Fatalf("ApplyProfile() error = %v", err)
+			}
+
+			got, err := ResolveAPIToken(t.Context(), v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveAPIToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ResolveAPIToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+// Package keyring stores the master API token of the goGenerateCFToken CLI tool outside
+// of its configuration file.
+//
+// On Linux, the token is kept in the freedesktop Secret Service (e.g., GNOME Keyring or
+// KeePassXC), reached over the D-Bus session bus. The item is labelled with the service
+// "goGenerateCFToken" and the username "api_token", so that it can also be inspected
+// with tools such as secret-tool. When no D-Bus session exists, or no Secret Service is
+// running on it, and on other operating systems, the token is instead kept in a file
+// encrypted with AES-256-GCM under a key derived from a passphrase.
+//
+// Key components:
+// - Store: Reads, writes, and deletes the stored token.
+// - Open: Opens the Secret Service, falling back to the encrypted file.
+// - NewFileStore: Opens the encrypted file directly.
+package keyring
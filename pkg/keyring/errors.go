@@ -0,0 +1,43 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import "errors"
+
+var (
+	// ErrNotFound indicates that no master API token is stored.
+	ErrNotFound = errors.New("no master API token stored")
+
+	// ErrSecretServiceUnavailable indicates that there is no D-Bus session, or no Secret Service on it.
+	ErrSecretServiceUnavailable = errors.New("secret service unavailable")
+
+	// ErrSecretServiceFailed indicates a failed Secret Service call.
+	ErrSecretServiceFailed = errors.New("secret service request failed")
+
+	// ErrPromptDismissed indicates that the user dismissed a Secret Service unlock or confirmation prompt.
+	ErrPromptDismissed = errors.New("secret service prompt dismissed")
+
+	// ErrNoPassphrase indicates that the encrypted token file was to be used without a passphrase.
+	ErrNoPassphrase = errors.New("a passphrase is required for the encrypted token file")
+
+	// ErrWrongPassphrase indicates an encrypted token file that could not be decrypted with the passphrase.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted token file")
+
+	// ErrInvalidTokenFile indicates an encrypted token file in an unknown format.
+	ErrInvalidTokenFile = errors.New("invalid encrypted token file")
+)
@@ -0,0 +1,228 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/nicholas-fedor/gogeneratecftoken/pkg/atomicfile"
+)
+
+// Constants defining the encrypted token file.
+const (
+	// fileVersion is the format version of the encrypted token file.
+	fileVersion = 1
+	// fileKDF names the key derivation function of the encrypted token file.
+	fileKDF = "pbkdf2-sha256"
+	// fileMode restricts the encrypted token file to its owner.
+	fileMode = 0o600
+	// fileDirMode restricts the directory created for the encrypted token file to its owner.
+	fileDirMode = 0o700
+	// keyLength is the length of the AES-256 key.
+	keyLength = 32
+	// saltLength is the length of the random key derivation salt.
+	saltLength = 16
+)
+
+// kdfIterations is the number of PBKDF2 iterations used for new files.
+// It can be lowered for testing.
+var kdfIterations = 600000
+
+// encryptedFile is the JSON document stored in the encrypted token file.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// fileStore keeps the master API token in a passphrase-encrypted file.
+type fileStore struct {
+	path       string
+	passphrase func() (string, error)
+}
+
+// NewFileStore returns a store that keeps the token in the file at path, encrypted with
+// a key derived from the passphrase returned by passphrase.
+func NewFileStore(path string, passphrase func() (string, error)) Store {
+	return &fileStore{path: path, passphrase: passphrase}
+}
+
+// Name describes the encrypted file.
+func (s *fileStore) Name() string {
+	return "encrypted file " + s.path
+}
+
+// Get decrypts and returns the stored token. The passphrase is only requested if the file exists.
+func (s *fileStore) Get() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	var file encryptedFile
+
+	err = json.Unmarshal(data, &file)
+	if err != nil || file.Version != fileVersion || file.KDF != fileKDF || file.Iterations < 1 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidTokenFile, s.path)
+	}
+
+	passphrase, err := s.readPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return "", err
+	}
+
+	if len(file.Nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidTokenFile, s.path)
+	}
+
+	token, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+
+	return string(token), nil
+}
+
+// Set encrypts the token under a fresh salt and nonce and writes it to the file.
+func (s *fileStore) Set(token string) error {
+	passphrase, err := s.readPassphrase()
+	if err != nil {
+		return err
+	}
+
+	file := encryptedFile{
+		Version:    fileVersion,
+		KDF:        fileKDF,
+		Iterations: kdfIterations,
+		Salt:       make([]byte, saltLength),
+	}
+
+	_, err = rand.Read(file.Salt)
+	if err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+
+	file.Nonce = make([]byte, gcm.NonceSize())
+
+	_, err = rand.Read(file.Nonce)
+	if err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, []byte(token), nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", s.path, err)
+	}
+
+	return writeFile(s.path, append(data, '\n'))
+}
+
+// Delete removes the file.
+func (s *fileStore) Delete() error {
+	err := os.Remove(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", s.path, err)
+	}
+
+	return nil
+}
+
+// Close does nothing, as the file is not kept open.
+func (s *fileStore) Close() error {
+	return nil
+}
+
+// readPassphrase returns the passphrase of the file, refusing an empty one.
+func (s *fileStore) readPassphrase() (string, error) {
+	if s.passphrase == nil {
+		return "", ErrNoPassphrase
+	}
+
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+
+	return passphrase, nil
+}
+
+// newCipher derives the file key from the passphrase and returns an AES-GCM cipher using it.
+func newCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// writeFile writes data to path with owner-only permissions, creating its directory if
+// needed. The file is written atomically, so that a failed write never destroys the
+// stored token.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+
+	err := os.MkdirAll(dir, fileDirMode)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	return atomicfile.Write(path, data, fileMode, true)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import (
+	"errors"
+	"runtime"
+)
+
+// Constants identifying the stored master API token.
+const (
+	// Service is the service attribute of the stored token.
+	Service = "goGenerateCFToken"
+	// Username is the username attribute of the stored token.
+	Username = "api_token"
+)
+
+// goos holds the operating system type for selecting the backend.
+// It defaults to runtime.GOOS but can be overridden for testing.
+var goos = runtime.GOOS
+
+// Store holds the master API token.
+type Store interface {
+	// Name describes where the token is stored.
+	Name() string
+
+	// Get returns the stored token, or ErrNotFound if none is stored.
+	Get() (string, error)
+
+	// Set stores the token, replacing any stored token.
+	Set(token string) error
+
+	// Delete removes the stored token, or returns ErrNotFound if none is stored.
+	Delete() error
+
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// Options configures the fallback to an encrypted file.
+type Options struct {
+	// FilePath is the encrypted file used when the Secret Service is unavailable.
+	FilePath string
+
+	// Passphrase returns the passphrase of the encrypted file. It is only called when
+	// the file is read or written.
+	Passphrase func() (string, error)
+}
+
+// Open returns the Secret Service store on Linux. If there is no D-Bus session, or no
+// Secret Service on it, and on other operating systems, it returns the encrypted file
+// store instead.
+func Open(opts Options) (Store, error) {
+	if goos == "linux" {
+		store, err := openSecretService()
+
+		switch {
+		case err == nil:
+			return store, nil
+		case !errors.Is(err, ErrSecretServiceUnavailable):
+			return nil, err
+		}
+	}
+
+	return NewFileStore(opts.FilePath, opts.Passphrase), nil
+}
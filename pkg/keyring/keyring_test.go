@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	originalIterations := kdfIterations
+
+	defer func() { kdfIterations = originalIterations }()
+
+	// Keep the key derivation fast in tests.
+	kdfIterations = 1000
+
+	passphrase := func(value string) func() (string, error) {
+		return func() (string, error) { return value, nil }
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring", "token.json")
+		store := NewFileStore(path, passphrase("secret"))
+
+		err := store.Set("master-token")
+		if err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+
+		if mode := info.Mode().Perm(); runtime.GOOS != "windows" && mode != fileMode {
+			t.Errorf("file mode = %v, want %v", mode, os.FileMode(fileMode))
+		}
+
+		got, err := store.Get()
+		if err != nil || got != "master-token" {
+			t.Errorf("Get() = %q, %v, want %q", got, err, "master-token")
+		}
+
+		_, err = NewFileStore(path, passphrase("wrong")).Get()
+		if !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Get() with wrong passphrase error = %v, want %v", err, ErrWrongPassphrase)
+		}
+
+		err = store.Delete()
+		if err != nil {
+			t.Errorf("Delete() error = %v", err)
+		}
+
+		_, err = store.Get()
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.json")
+
+		// The passphrase must not be requested for a file that does not exist.
+		store := NewFileStore(path, func() (string, error) {
+			t.Error("passphrase requested for a missing file")
+
+			return "", nil
+		})
+
+		_, err := store.Get()
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+
+		err = store.Delete()
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("EmptyPassphrase", func(t *testing.T) {
+		err := NewFileStore(filepath.Join(t.TempDir(), "token.json"), passphrase("")).Set("master-token")
+		if !errors.Is(err, ErrNoPassphrase) {
+			t.Errorf("Set() error = %v, want %v", err, ErrNoPassphrase)
+		}
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.json")
+
+		err := os.WriteFile(path, []byte(`{"version": 2}`), 0o600)
+		if err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		_, err = NewFileStore(path, passphrase("secret")).Get()
+		if !errors.Is(err, ErrInvalidTokenFile) {
+			t.Errorf("Get() error = %v, want %v", err, ErrInvalidTokenFile)
+		}
+	})
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		setup    func(t *testing.T)
+		wantName string
+	}{
+		{
+			name:     "SecretService",
+			goos:     "linux",
+			setup:    func(t *testing.T) { t.Helper(); startSecretService(t, false, true, false) },
+			wantName: "Secret Service keyring",
+		},
+		{
+			name: "NoSession",
+			goos: "linux",
+			setup: func(t *testing.T) {
+				t.Helper()
+				t.Setenv("DBUS_SESSION_BUS_ADDRESS", "")
+				t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+			},
+			wantName: "encrypted file token.json",
+		},
+		{
+			name:     "NotLinux",
+			goos:     "darwin",
+			setup:    func(t *testing.T) { t.Helper(); startSecretService(t, false, true, false) },
+			wantName: "encrypted file token.json",
+		},
+	}
+
+	originalGOOS := goos
+
+	defer func() { goos = originalGOOS }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+
+			goos = tt.goos
+
+			store, err := Open(Options{FilePath: "token.json"})
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+
+			defer store.Close()
+
+			if got := store.Name(); got != tt.wantName {
+				t.Errorf("Open().Name() = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
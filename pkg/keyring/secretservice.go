@@ -0,0 +1,367 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Constants defining the freedesktop Secret Service D-Bus API.
+const (
+	secretsBusName      = "org.freedesktop.secrets"
+	secretsPath         = dbus.ObjectPath("/org/freedesktop/secrets")
+	serviceInterface    = "org.freedesktop.Secret.Service"
+	collectionInterface = "org.freedesktop.Secret.Collection"
+	itemInterface       = "org.freedesktop.Secret.Item"
+	sessionInterface    = "org.freedesktop.Secret.Session"
+	promptInterface     = "org.freedesktop.Secret.Prompt"
+
+	// noObject is the path returned in place of a missing collection or prompt.
+	noObject = dbus.ObjectPath("/")
+	// defaultAlias is the alias of the collection new items are created in.
+	defaultAlias = "default"
+	// plainAlgorithm transfers secrets unencrypted, which is safe over the private session bus.
+	plainAlgorithm = "plain"
+
+	// itemLabel is the label of the stored token, shown by keyring managers.
+	itemLabel = "goGenerateCFToken master API token"
+	// collectionLabel is the label of the default collection, if it has to be created.
+	collectionLabel = "Login"
+)
+
+// secret is a secret as transferred by the Secret Service API.
+type secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// secretService keeps the master API token in the freedesktop Secret Service.
+type secretService struct {
+	conn    *dbus.Conn
+	session dbus.ObjectPath
+}
+
+// openSecretService connects to the Secret Service on the D-Bus session bus and opens a
+// session with it. It returns ErrSecretServiceUnavailable if there is no session bus, or
+// no Secret Service on it.
+func openSecretService() (*secretService, error) {
+	address := sessionBusAddress()
+	if address == "" {
+		return nil, fmt.Errorf("%w: no D-Bus session", ErrSecretServiceUnavailable)
+	}
+
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretServiceUnavailable, err)
+	}
+
+	s := &secretService{conn: conn}
+
+	var output dbus.Variant
+
+	err = s.service().
+		Call(serviceInterface+".OpenSession", 0, plainAlgorithm, dbus.MakeVariant("")).
+		Store(&output, &s.session)
+	if err != nil {
+		conn.Close()
+
+		if serviceMissing(err) {
+			return nil, fmt.Errorf("%w: %w", ErrSecretServiceUnavailable, err)
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	return s, nil
+}
+
+// sessionBusAddress returns the address of the D-Bus session bus, or an empty string if
+// there is none. Unlike dbus.SessionBus, it never launches a new bus.
+func sessionBusAddress() string {
+	if address := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); address != "" {
+		return address
+	}
+
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		path := filepath.Join(dir, "bus")
+		if _, err := os.Stat(path); err == nil {
+			return "unix:path=" + path
+		}
+	}
+
+	return ""
+}
+
+// serviceMissing reports whether err indicates that no Secret Service is running.
+func serviceMissing(err error) bool {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return false
+	}
+
+	return dbusErr.Name == "org.freedesktop.DBus.Error.ServiceUnknown" ||
+		strings.HasPrefix(dbusErr.Name, "org.freedesktop.DBus.Error.Spawn.")
+}
+
+// Name describes the Secret Service.
+func (s *secretService) Name() string {
+	return "Secret Service keyring"
+}
+
+// Get returns the secret of the stored item, unlocking it if needed.
+func (s *secretService) Get() (string, error) {
+	items, err := s.searchItems()
+	if err != nil {
+		return "", err
+	}
+
+	if len(items) == 0 {
+		return "", ErrNotFound
+	}
+
+	var value secret
+
+	err = s.object(items[0]).Call(itemInterface+".GetSecret", 0, s.session).Store(&value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	return string(value.Value), nil
+}
+
+// Set stores the token in the default collection, replacing the stored item.
+func (s *secretService) Set(token string) error {
+	collection, err := s.defaultCollection()
+	if err != nil {
+		return err
+	}
+
+	err = s.unlock(collection)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]dbus.Variant{
+		itemInterface + ".Label":      dbus.MakeVariant(itemLabel),
+		itemInterface + ".Attributes": dbus.MakeVariant(attributes()),
+	}
+
+	value := secret{
+		Session:     s.session,
+		Parameters:  []byte{},
+		Value:       []byte(token),
+		ContentType: "text/plain",
+	}
+
+	var item, prompt dbus.ObjectPath
+
+	err = s.object(collection).
+		Call(collectionInterface+".CreateItem", 0, properties, value, true).
+		Store(&item, &prompt)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	_, err = s.prompt(prompt)
+
+	return err
+}
+
+// Delete deletes every stored item.
+func (s *secretService) Delete() error {
+	items, err := s.searchItems()
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return ErrNotFound
+	}
+
+	for _, item := range items {
+		var prompt dbus.ObjectPath
+
+		err = s.object(item).Call(itemInterface+".Delete", 0).Store(&prompt)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+		}
+
+		_, err = s.prompt(prompt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the session and the connection.
+func (s *secretService) Close() error {
+	err := s.object(s.session).Call(sessionInterface+".Close", 0).Err
+
+	closeErr := s.conn.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// service returns the Secret Service object.
+func (s *secretService) service() dbus.BusObject {
+	return s.object(secretsPath)
+}
+
+// object returns a Secret Service object by path.
+func (s *secretService) object(path dbus.ObjectPath) dbus.BusObject {
+	return s.conn.Object(secretsBusName, path)
+}
+
+// attributes returns the attributes identifying the stored token.
+func attributes() map[string]string {
+	return map[string]string{"service": Service, "username": Username}
+}
+
+// searchItems returns the stored items, unlocking any locked ones.
+func (s *secretService) searchItems() ([]dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+
+	err := s.service().Call(serviceInterface+".SearchItems", 0, attributes()).Store(&unlocked, &locked)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	if len(locked) > 0 {
+		err = s.unlock(locked...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(unlocked, locked...), nil
+}
+
+// defaultCollection returns the default collection, creating it if there is none.
+func (s *secretService) defaultCollection() (dbus.ObjectPath, error) {
+	var collection dbus.ObjectPath
+
+	err := s.service().Call(serviceInterface+".ReadAlias", 0, defaultAlias).Store(&collection)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	if collection != noObject {
+		return collection, nil
+	}
+
+	properties := map[string]dbus.Variant{
+		collectionInterface + ".Label": dbus.MakeVariant(collectionLabel),
+	}
+
+	var prompt dbus.ObjectPath
+
+	err = s.service().
+		Call(serviceInterface+".CreateCollection", 0, properties, defaultAlias).
+		Store(&collection, &prompt)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	// A collection created through a prompt is returned as the prompt's result.
+	if collection == noObject {
+		result, err := s.prompt(prompt)
+		if err != nil {
+			return "", err
+		}
+
+		collection, _ = result.Value().(dbus.ObjectPath)
+	}
+
+	return collection, nil
+}
+
+// unlock unlocks the given objects, prompting the user if needed.
+func (s *secretService) unlock(objects ...dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+
+	var prompt dbus.ObjectPath
+
+	err := s.service().Call(serviceInterface+".Unlock", 0, objects).Store(&unlocked, &prompt)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	_, err = s.prompt(prompt)
+
+	return err
+}
+
+// prompt shows a Secret Service prompt, if one is needed, and waits for the user to
+// complete it. It returns the result of the prompt.
+func (s *secretService) prompt(path dbus.ObjectPath) (dbus.Variant, error) {
+	if path == noObject || path == "" {
+		return dbus.Variant{}, nil
+	}
+
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface(promptInterface),
+		dbus.WithMatchMember("Completed"),
+	}
+
+	err := s.conn.AddMatchSignal(match...)
+	if err != nil {
+		return dbus.Variant{}, fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	defer s.conn.RemoveMatchSignal(match...)
+
+	signals := make(chan *dbus.Signal, 1)
+
+	s.conn.Signal(signals)
+	defer s.conn.RemoveSignal(signals)
+
+	err = s.object(path).Call(promptInterface+".Prompt", 0, "").Err
+	if err != nil {
+		return dbus.Variant{}, fmt.Errorf("%w: %w", ErrSecretServiceFailed, err)
+	}
+
+	for signal := range signals {
+		if signal.Path != path || signal.Name != promptInterface+".Completed" || len(signal.Body) != 2 {
+			continue
+		}
+
+		if dismissed, _ := signal.Body[0].(bool); dismissed {
+			return dbus.Variant{}, ErrPromptDismissed
+		}
+
+		result, _ := signal.Body[1].(dbus.Variant)
+
+		return result, nil
+	}
+
+	return dbus.Variant{}, fmt.Errorf("%w: connection closed during prompt", ErrSecretServiceFailed)
+}
@@ -0,0 +1,438 @@
+/*
+Copyright © 2026 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package keyring
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Paths of the objects exported by the fake Secret Service.
+const (
+	fakeCollectionPath = dbus.ObjectPath("/org/freedesktop/secrets/collection/login")
+	fakeSessionPath    = dbus.ObjectPath("/org/freedesktop/secrets/session/1")
+	fakePromptPath     = dbus.ObjectPath("/org/freedesktop/secrets/prompt/1")
+)
+
+// fakeSecretService implements the parts of the Secret Service API used by the store.
+// Locked items and a missing default collection are handled through a prompt, which the
+// user completes or dismisses.
+type fakeSecretService struct {
+	conn *dbus.Conn
+
+	mu            sync.Mutex
+	locked        bool
+	hasCollection bool
+	dismiss       bool
+	nextItem      int
+	items         map[dbus.ObjectPath]*fakeItem
+	pending       func() dbus.Variant
+}
+
+// fakeItem is an item stored in the fake Secret Service.
+type fakeItem struct {
+	service    *fakeSecretService
+	path       dbus.ObjectPath
+	attributes map[string]string
+	value      []byte
+}
+
+// fakeCollection exports the Collection interface of the fake default collection.
+type fakeCollection struct {
+	service *fakeSecretService
+}
+
+// fakeSession exports the Session interface of the fake session.
+type fakeSession struct{}
+
+// fakePrompt exports the Prompt interface of the fake prompt.
+type fakePrompt struct {
+	service *fakeSecretService
+}
+
+// startBus starts a private D-Bus session bus and points DBUS_SESSION_BUS_ADDRESS at it.
+// The test is skipped if dbus-daemon is not installed.
+func startBus(t *testing.T) string {
+	t.Helper()
+
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not installed")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address")
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("Failed to start dbus-daemon: %v", err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		t.Fatalf("Failed to start dbus-daemon: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("Failed to read the dbus-daemon address: %v", err)
+	}
+
+	address = strings.TrimSpace(address)
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", address)
+
+	return address
+}
+
+// startSecretService runs a fake Secret Service on a private session bus.
+func startSecretService(t *testing.T, locked, hasCollection, dismiss bool) *fakeSecretService {
+	t.Helper()
+
+	conn, err := dbus.Connect(startBus(t))
+	if err != nil {
+		t.Fatalf("Failed to connect to dbus-daemon: %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	fake := &fakeSecretService{
+		conn:          conn,
+		locked:        locked,
+		hasCollection: hasCollection,
+		dismiss:       dismiss,
+		items:         map[dbus.ObjectPath]*fakeItem{},
+	}
+
+	exports := []struct {
+		object any
+		path   dbus.ObjectPath
+		iface  string
+	}{
+		{fake, secretsPath, serviceInterface},
+		{&fakeCollection{fake}, fakeCollectionPath, collectionInterface},
+		{&fakeSession{}, fakeSessionPath, sessionInterface},
+		{&fakePrompt{fake}, fakePromptPath, promptInterface},
+	}
+
+	for _, export := range exports {
+		err = conn.Export(export.object, export.path, export.iface)
+		if err != nil {
+			t.Fatalf("Failed to export %s: %v", export.path, err)
+		}
+	}
+
+	reply, err := conn.RequestName(secretsBusName, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("Failed to own %s: %v", secretsBusName, err)
+	}
+
+	return fake
+}
+
+// itemCount returns the number of stored items.
+func (f *fakeSecretService) itemCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.items)
+}
+
+// OpenSession opens the fake session, supporting only plain transfers.
+func (f *fakeSecretService) OpenSession(algorithm string, _ dbus.Variant) (dbus.Variant, dbus.ObjectPath, *dbus.Error) {
+	if algorithm != plainAlgorithm {
+		return dbus.Variant{}, noObject, dbus.NewError("org.freedesktop.DBus.Error.NotSupported", nil)
+	}
+
+	return dbus.MakeVariant(""), fakeSessionPath, nil
+}
+
+// ReadAlias returns the default collection, if it exists.
+func (f *fakeSecretService) ReadAlias(name string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if name != defaultAlias || !f.hasCollection {
+		return noObject, nil
+	}
+
+	return fakeCollectionPath, nil
+}
+
+// CreateCollection creates the default collection through a prompt.
+func (f *fakeSecretService) CreateCollection(_ map[string]dbus.Variant, _ string) (dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending = func() dbus.Variant {
+		f.hasCollection = true
+
+		return dbus.MakeVariant(fakeCollectionPath)
+	}
+
+	return noObject, fakePromptPath, nil
+}
+
+// SearchItems returns the items with all of the given attributes.
+func (f *fakeSecretService) SearchItems(attributes map[string]string) ([]dbus.ObjectPath, []dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var found []dbus.ObjectPath
+
+	for path, item := range f.items {
+		if maps.Equal(item.attributes, attributes) {
+			found = append(found, path)
+		}
+	}
+
+	if f.locked {
+		return []dbus.ObjectPath{}, found, nil
+	}
+
+	return found, []dbus.ObjectPath{}, nil
+}
+
+// Unlock unlocks everything through a prompt, if the fake is locked.
+func (f *fakeSecretService) Unlock(objects []dbus.ObjectPath) ([]dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.locked {
+		return objects, noObject, nil
+	}
+
+	f.pending = func() dbus.Variant {
+		f.locked = false
+
+		return dbus.MakeVariant(objects)
+	}
+
+	return []dbus.ObjectPath{}, fakePromptPath, nil
+}
+
+// CreateItem stores a secret in the default collection.
+func (c *fakeCollection) CreateItem(
+	properties map[string]dbus.Variant,
+	value secret,
+	replace bool,
+) (dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	f := c.service
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.locked {
+		return noObject, noObject, dbus.NewError("org.freedesktop.Secret.Error.IsLocked", nil)
+	}
+
+	attributes, _ := properties[itemInterface+".Attributes"].Value().(map[string]string)
+
+	if replace {
+		for path, item := range f.items {
+			if maps.Equal(item.attributes, attributes) {
+				item.value = value.Value
+
+				return path, noObject, nil
+			}
+		}
+	}
+
+	f.nextItem++
+	item := &fakeItem{
+		service:    f,
+		path:       dbus.ObjectPath(fmt.Sprintf("%s/%d", fakeCollectionPath, f.nextItem)),
+		attributes: attributes,
+		value:      value.Value,
+	}
+
+	f.items[item.path] = item
+
+	err := f.conn.Export(item, item.path, itemInterface)
+	if err != nil {
+		return noObject, noObject, dbus.MakeFailedError(err)
+	}
+
+	return item.path, noObject, nil
+}
+
+// GetSecret returns the secret of an unlocked item.
+func (i *fakeItem) GetSecret(session dbus.ObjectPath) (secret, *dbus.Error) {
+	i.service.mu.Lock()
+	defer i.service.mu.Unlock()
+
+	if i.service.locked {
+		return secret{}, dbus.NewError("org.freedesktop.Secret.Error.IsLocked", nil)
+	}
+
+	return secret{Session: session, Parameters: []byte{}, Value: i.value, ContentType: "text/plain"}, nil
+}
+
+// Delete deletes the item.
+func (i *fakeItem) Delete() (dbus.ObjectPath, *dbus.Error) {
+	i.service.mu.Lock()
+	defer i.service.mu.Unlock()
+
+	delete(i.service.items, i.path)
+
+	err := i.service.conn.Export(nil, i.path, itemInterface)
+	if err != nil {
+		return noObject, dbus.MakeFailedError(err)
+	}
+
+	return noObject, nil
+}
+
+// Close closes the fake session.
+func (fakeSession) Close() *dbus.Error {
+	return nil
+}
+
+// Prompt completes or dismisses the pending action, as configured.
+func (p *fakePrompt) Prompt(_ string) *dbus.Error {
+	f := p.service
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := dbus.MakeVariant("")
+	if !f.dismiss && f.pending != nil {
+		result = f.pending()
+	}
+
+	f.pending = nil
+
+	err := f.conn.Emit(fakePromptPath, promptInterface+".Completed", f.dismiss, result)
+	if err != nil {
+		return dbus.MakeFailedError(err)
+	}
+
+	return nil
+}
+
+func TestSecretService(t *testing.T) {
+	tests := []struct {
+		name          string
+		locked        bool
+		hasCollection bool
+		dismiss       bool
+		wantErr       error
+	}{
+		{
+			name:          "Unlocked",
+			hasCollection: true,
+		},
+		{
+			name:          "Locked",
+			locked:        true,
+			hasCollection: true,
+		},
+		{
+			name: "NoDefaultCollection",
+		},
+		{
+			name:          "PromptDismissed",
+			locked:        true,
+			hasCollection: true,
+			dismiss:       true,
+			wantErr:       ErrPromptDismissed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := startSecretService(t, tt.locked, tt.hasCollection, tt.dismiss)
+
+			store, err := openSecretService()
+			if err != nil {
+				t.Fatalf("openSecretService() error = %v", err)
+			}
+
+			defer store.Close()
+
+			err = store.Set("first-token")
+			if err == nil {
+				err = store.Set("master-token")
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if count := fake.itemCount(); count != 1 {
+				t.Errorf("Set() stored %d items, want 1", count)
+			}
+
+			got, err := store.Get()
+			if err != nil || got != "master-token" {
+				t.Errorf("Get() = %q, %v, want %q", got, err, "master-token")
+			}
+
+			err = store.Delete()
+			if err != nil {
+				t.Errorf("Delete() error = %v", err)
+			}
+
+			_, err = store.Get()
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+			}
+
+			err = store.Delete()
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Delete() after Delete() error = %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestOpenSecretService_Unavailable(t *testing.T) {
+	t.Run("NoSession", func(t *testing.T) {
+		t.Setenv("DBUS_SESSION_BUS_ADDRESS", "")
+		t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+		_, err := openSecretService()
+		if !errors.Is(err, ErrSecretServiceUnavailable) {
+			t.Errorf("openSecretService() error = %v, want %v", err, ErrSecretServiceUnavailable)
+		}
+	})
+
+	t.Run("NoSecretService", func(t *testing.T) {
+		startBus(t)
+
+		_, err := openSecretService()
+		if !errors.Is(err, ErrSecretServiceUnavailable) {
+			t.Errorf("openSecretService() error = %v, want %v", err, ErrSecretServiceUnavailable)
+		}
+	})
+}